@@ -3,23 +3,49 @@ package api
 import "net/http"
 
 type Api struct {
-	Get    Get
-	Search Search
-	Query  *Query
+	Get        Get
+	Create     Create
+	Update     Update
+	Delete     Delete
+	Upsert     Upsert
+	Search     Search
+	SearchIter SearchIter
+	Limits     Limits
+	Query      *Query
 }
 
 type Query struct {
-	Select Select
-	Raw    RawQuery
+	Select     Select
+	SelectIter SelectIter
+	Raw        RawQuery
+	Iter       Iter
+	More       More
+	Explain    Explain
+	Count      Count
+	Chunked    Chunked
+	InChunks   InChunks
 }
 
 func New(base Transport) *Api {
 	return &Api{
-		Get:    newGetFunc(base),
-		Search: newSearchFunc(base),
+		Get:        newGetFunc(base),
+		Create:     newCreateFunc(base),
+		Update:     newUpdateFunc(base),
+		Delete:     newDeleteFunc(base),
+		Upsert:     newUpsertFunc(base),
+		Search:     newSearchFunc(base),
+		SearchIter: newSearchIterFunc(base),
+		Limits:     newLimitsFunc(base),
 		Query: &Query{
-			Select: newSelectFunc(base),
-			Raw:    newRawQueryFunc(base),
+			Select:     newSelectFunc(base),
+			SelectIter: newSelectIterFunc(base),
+			Raw:        newRawQueryFunc(base),
+			Iter:       newIterFunc(base),
+			More:       newMoreFunc(base),
+			Explain:    newExplainFunc(base),
+			Count:      newCountFunc(base),
+			Chunked:    newChunkedFunc(base),
+			InChunks:   newInChunksFunc(base),
 		},
 	}
 }
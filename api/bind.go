@@ -0,0 +1,109 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Named binds value to the :name placeholder of a raw query. The value is formatted with FormatLiteral, so slices
+// can be bound to IN clauses.
+//
+//	client.Query.Raw("SELECT Id FROM Contact WHERE Email = :email", api.Named("email", email))
+func Named(name string, value interface{}) RawQueryOption {
+	return func(r *RawQueryRequest) error {
+		if name == "" {
+			return errors.New("bind name cannot be empty")
+		}
+		if r.Named == nil {
+			r.Named = map[string]interface{}{}
+		}
+		r.Named[name] = value
+		return nil
+	}
+}
+
+// Args binds values in order to the ? placeholders of a raw query. The values are formatted with FormatLiteral.
+//
+//	client.Query.Raw("SELECT Id FROM Contact WHERE LastName = ? AND CreatedDate > ?", api.Args(name, since))
+func Args(values ...interface{}) RawQueryOption {
+	return func(r *RawQueryRequest) error {
+		r.Args = append(r.Args, values...)
+		return nil
+	}
+}
+
+// bind replaces the placeholders of query with the formatted values. Placeholders inside string literals are left
+// alone, as are colons not followed by a name, e.g. in LAST_N_DAYS:30.
+func bind(query string, named map[string]interface{}, args []interface{}) (string, error) {
+	var (
+		out      strings.Builder
+		inString bool
+		next     int
+	)
+
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+
+		if inString {
+			out.WriteByte(c)
+			if c == '\\' && i+1 < len(query) {
+				i++
+				out.WriteByte(query[i])
+			} else if c == '\'' {
+				inString = false
+			}
+			continue
+		}
+
+		switch {
+		case c == '\'':
+			inString = true
+			out.WriteByte(c)
+		case c == '?':
+			if next >= len(args) {
+				return "", errors.New("not enough arguments for ? placeholders")
+			}
+			literal, err := FormatLiteral(args[next])
+			if err != nil {
+				return "", err
+			}
+			next++
+			out.WriteString(literal)
+		case c == ':' && i+1 < len(query) && isNameStart(query[i+1]):
+			end := i + 1
+			for end < len(query) && isNamePart(query[end]) {
+				end++
+			}
+			name := query[i+1 : end]
+			value, ok := named[name]
+			if !ok {
+				return "", fmt.Errorf("no value bound to :%v", name)
+			}
+			literal, err := FormatLiteral(value)
+			if err != nil {
+				return "", err
+			}
+			out.WriteString(literal)
+			i = end - 1
+		default:
+			out.WriteByte(c)
+		}
+	}
+
+	if inString {
+		return "", errors.New("unterminated string literal in query")
+	}
+	if next < len(args) {
+		return "", fmt.Errorf("%d arguments given but only %d ? placeholders found", len(args), next)
+	}
+	return out.String(), nil
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isNamePart(c byte) bool {
+	return isNameStart(c) || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,134 @@
+package api
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBind(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		named map[string]interface{}
+		args  []interface{}
+		want  string
+		err   string
+	}{
+		{
+			name:  "named string",
+			query: "SELECT Id FROM Contact WHERE LastName = :name",
+			named: map[string]interface{}{"name": "O'Brien"},
+			want:  `SELECT Id FROM Contact WHERE LastName = 'O\'Brien'`,
+		},
+		{
+			name:  "positional values",
+			query: "SELECT Id FROM Contact WHERE LastName = ? AND Age__c > ? AND IsActive__c = ?",
+			args:  []interface{}{"Smith", 42, true},
+			want:  "SELECT Id FROM Contact WHERE LastName = 'Smith' AND Age__c > 42 AND IsActive__c = true",
+		},
+		{
+			name:  "injection attempt stays inside the literal",
+			query: "SELECT Id FROM Contact WHERE LastName = ?",
+			args:  []interface{}{`x' OR Name != '`},
+			want:  `SELECT Id FROM Contact WHERE LastName = 'x\' OR Name != \''`,
+		},
+		{
+			name:  "placeholders inside quoted strings",
+			query: "SELECT Id FROM Contact WHERE Title = 'Why? :because' AND LastName = ?",
+			args:  []interface{}{"Smith"},
+			want:  "SELECT Id FROM Contact WHERE Title = 'Why? :because' AND LastName = 'Smith'",
+		},
+		{
+			name:  "escaped quote inside quoted string",
+			query: `SELECT Id FROM Contact WHERE LastName = 'O\'Brien ?' AND FirstName = :first`,
+			named: map[string]interface{}{"first": "Pat"},
+			want:  `SELECT Id FROM Contact WHERE LastName = 'O\'Brien ?' AND FirstName = 'Pat'`,
+		},
+		{
+			name:  "escaped backslash before closing quote",
+			query: `SELECT Id FROM Contact WHERE Title = 'a\\' AND LastName = ?`,
+			args:  []interface{}{"Smith"},
+			want:  `SELECT Id FROM Contact WHERE Title = 'a\\' AND LastName = 'Smith'`,
+		},
+		{
+			name:  "date literal with colon",
+			query: "SELECT Id FROM Contact WHERE CreatedDate = LAST_N_DAYS:30 AND LastName = ?",
+			args:  []interface{}{"Smith"},
+			want:  "SELECT Id FROM Contact WHERE CreatedDate = LAST_N_DAYS:30 AND LastName = 'Smith'",
+		},
+		{
+			name:  "datetime literal with colons",
+			query: "SELECT Id FROM Contact WHERE CreatedDate > 2024-01-01T10:30:00+01:00 AND LastName = :name",
+			named: map[string]interface{}{"name": "Smith"},
+			want:  "SELECT Id FROM Contact WHERE CreatedDate > 2024-01-01T10:30:00+01:00 AND LastName = 'Smith'",
+		},
+		{
+			name:  "bound time",
+			query: "SELECT Id FROM Contact WHERE CreatedDate > :since",
+			named: map[string]interface{}{"since": time.Date(2024, 1, 1, 10, 30, 0, 0, time.FixedZone("", 3600))},
+			want:  "SELECT Id FROM Contact WHERE CreatedDate > 2024-01-01T09:30:00Z",
+		},
+		{
+			name:  "slice bound to IN",
+			query: "SELECT Id FROM Contact WHERE Id IN :ids AND LeadSource IN ?",
+			named: map[string]interface{}{"ids": []string{"003A", "003B"}},
+			args:  []interface{}{[]interface{}{"Web", "O'Reilly"}},
+			want:  `SELECT Id FROM Contact WHERE Id IN ('003A', '003B') AND LeadSource IN ('Web', 'O\'Reilly')`,
+		},
+		{
+			name:  "empty slice",
+			query: "SELECT Id FROM Contact WHERE Id IN :ids",
+			named: map[string]interface{}{"ids": []string{}},
+			err:   "empty",
+		},
+		{
+			name:  "unterminated string",
+			query: "SELECT Id FROM Contact WHERE LastName = 'Smith AND FirstName = ?",
+			args:  []interface{}{"Pat"},
+			err:   "unterminated",
+		},
+		{
+			name:  "too few args",
+			query: "SELECT Id FROM Contact WHERE LastName = ? AND FirstName = ?",
+			args:  []interface{}{"Smith"},
+			err:   "not enough arguments",
+		},
+		{
+			name:  "too many args",
+			query: "SELECT Id FROM Contact WHERE LastName = ?",
+			args:  []interface{}{"Smith", "Pat"},
+			err:   "2 arguments given",
+		},
+		{
+			name:  "missing name",
+			query: "SELECT Id FROM Contact WHERE LastName = :name",
+			named: map[string]interface{}{"other": "Smith"},
+			err:   "no value bound to :name",
+		},
+		{
+			name:  "unsupported value",
+			query: "SELECT Id FROM Contact WHERE LastName = ?",
+			args:  []interface{}{struct{}{}},
+			err:   "cannot format",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := bind(tt.query, tt.named, tt.args)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("err = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("got  %v\nwant %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,419 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/0xArch3r/goforce/types"
+)
+
+// Chunked splits a query on object into disjoint ranges and runs them concurrently, yielding the records of all
+// ranges as they arrive and in no particular order. It is a REST stand-in for PK chunking on tables too large for a
+// single cursor. Ranges are cut by Id by default, or by CreatedDate windows with ByCreatedDate.
+//
+//	for record, err := range client.Query.Chunked("Task",
+//		client.Query.Chunked.Fields("Id", "Subject"),
+//		client.Query.Chunked.Chunks(16),
+//		client.Query.Chunked.Workers(4),
+//	) {
+//		...
+//	}
+type Chunked func(object string, opts ...ChunkedOption) iter.Seq2[types.SObject, error]
+
+type ChunkedOption func(*ChunkedRequest) error
+
+// ChunkedRequest configures a Chunked query.
+type ChunkedRequest struct {
+	ctx      context.Context
+	Object   string
+	Fields   []string
+	Where    Condition
+	Chunks   int
+	Workers  int
+	By       string
+	QueryAll bool
+}
+
+const (
+	chunkByID          = "Id"
+	chunkByCreatedDate = "CreatedDate"
+)
+
+func newChunkedFunc(base Transport) Chunked {
+	raw := newRawQueryFunc(base)
+	count := newCountFunc(base)
+	records := newIterFunc(base)
+
+	return func(object string, opts ...ChunkedOption) iter.Seq2[types.SObject, error] {
+		return func(yield func(types.SObject, error) bool) {
+			r := ChunkedRequest{
+				Object: object,
+				Fields: []string{"Id"},
+				Chunks: 4,
+				By:     chunkByID,
+			}
+			for _, f := range opts {
+				err := f(&r)
+				if err != nil {
+					yield(nil, err)
+					return
+				}
+			}
+
+			rawOpts := []RawQueryOption{raw.WithContext(r.ctx)}
+			if r.QueryAll {
+				rawOpts = append(rawOpts, raw.QueryAll())
+			}
+
+			var ranges []Condition
+			var err error
+			if r.By == chunkByCreatedDate {
+				ranges, err = r.dateRanges(raw, rawOpts)
+			} else {
+				ranges, err = r.idRanges(raw, count, rawOpts)
+			}
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			queries := make([]string, len(ranges))
+			for i, cond := range ranges {
+				where := cond
+				if r.Where != nil && cond != nil {
+					where = And(r.Where, cond)
+				} else if r.Where != nil {
+					where = r.Where
+				}
+				queries[i], err = SelectRequest{Object: r.Object, Fields: r.Fields, Where: where}.SOQL()
+				if err != nil {
+					yield(nil, err)
+					return
+				}
+			}
+
+			parallel(r.ctx, queries, r.Workers, func(ctx context.Context, query string) iter.Seq2[types.SObject, error] {
+				return records(query, append(rawOpts, raw.WithContext(ctx))...)
+			}, yield)
+		}
+	}
+}
+
+// idRange is a range of Ids holding count records, from the lowest Id lo to the highest Id hi found in it.
+type idRange struct {
+	lo, hi string
+	count  int
+	// final marks ranges that cannot be split any further.
+	final bool
+}
+
+// idRanges cuts the matching records into up to r.Chunks ranges of similar size. Ids are not spread evenly, e.g. the
+// characters following the key prefix change when an org moves to another instance, so the ranges are derived from
+// the data: the fullest range is repeatedly cut in the middle between its lowest and highest Id, and both halves are
+// narrowed to the Ids actually found in them. Every cut costs three small queries. Ranges are open-ended at both
+// ends so that records created meanwhile are not lost.
+func (r ChunkedRequest) idRanges(raw RawQuery, count Count, opts []RawQueryOption) ([]Condition, error) {
+	lowest, err := r.boundaryID(raw, opts, "ASC", nil)
+	if err != nil || lowest == "" {
+		return nil, err
+	}
+	highest, err := r.boundaryID(raw, opts, "DESC", nil)
+	if err != nil {
+		return nil, err
+	}
+	total, err := count(r.Object, r.Where, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	ranges := []idRange{{lo: lowest, hi: highest, count: total}}
+	for len(ranges) < r.Chunks {
+		fullest := -1
+		for i, rng := range ranges {
+			if !rng.final && rng.count > 1 && (fullest < 0 || rng.count > ranges[fullest].count) {
+				fullest = i
+			}
+		}
+		if fullest < 0 {
+			break
+		}
+
+		halves, err := r.splitRange(raw, count, opts, ranges[fullest])
+		if err != nil {
+			return nil, err
+		}
+		if halves == nil {
+			ranges[fullest].final = true
+			continue
+		}
+		ranges = append(ranges[:fullest], append(halves, ranges[fullest+1:]...)...)
+	}
+
+	conds := make([]Condition, 0, len(ranges))
+	for i := range ranges {
+		var bounds []Condition
+		if i > 0 {
+			bounds = append(bounds, Ge(chunkByID, ranges[i].lo))
+		}
+		if i < len(ranges)-1 {
+			bounds = append(bounds, Lt(chunkByID, ranges[i+1].lo))
+		}
+		conds = append(conds, rangeCondition(bounds))
+	}
+	return conds, nil
+}
+
+// splitRange cuts rng in the middle between its lowest and highest Id and narrows both halves to the records found
+// in them. It returns nil if rng cannot be split.
+func (r ChunkedRequest) splitRange(raw RawQuery, count Count, opts []RawQueryOption, rng idRange) ([]idRange, error) {
+	bounds, err := splitIDs(rng.lo, rng.hi, 2)
+	if err != nil || len(bounds) == 0 {
+		return nil, err
+	}
+	mid := bounds[0]
+
+	left := And(Ge(chunkByID, rng.lo), Lt(chunkByID, mid))
+	right := And(Ge(chunkByID, mid), Le(chunkByID, rng.hi))
+	if r.Where != nil {
+		left, right = And(r.Where, left), And(r.Where, right)
+	}
+
+	n, err := count(r.Object, left, opts...)
+	if err != nil {
+		return nil, err
+	}
+	leftHi, err := r.boundaryID(raw, opts, "DESC", left)
+	if err != nil {
+		return nil, err
+	}
+	rightLo, err := r.boundaryID(raw, opts, "ASC", right)
+	if err != nil {
+		return nil, err
+	}
+	// Either half may have been emptied by records deleted meanwhile.
+	if leftHi == "" || rightLo == "" {
+		return nil, nil
+	}
+
+	return []idRange{
+		{lo: rng.lo, hi: leftHi, count: n},
+		{lo: rightLo, hi: rng.hi, count: rng.count - n},
+	}, nil
+}
+
+// boundaryID returns the lowest or highest Id of the records matching where, or r.Where if where is nil, or "" if
+// there are none.
+func (r ChunkedRequest) boundaryID(raw RawQuery, opts []RawQueryOption, order string, where Condition) (string, error) {
+	if where == nil {
+		where = r.Where
+	}
+	query, err := SelectRequest{
+		Object:  r.Object,
+		Fields:  []string{chunkByID},
+		Where:   where,
+		OrderBy: chunkByID + " " + order,
+		Limit:   1,
+	}.SOQL()
+	if err != nil {
+		return "", err
+	}
+
+	res, err := raw(query, opts...)
+	if err != nil {
+		return "", err
+	}
+	if len(res.Records) == 0 {
+		return "", nil
+	}
+	return res.Records[0].ID(), nil
+}
+
+// dateRanges cuts the time between the oldest and the newest matching record into r.Chunks windows of equal length.
+func (r ChunkedRequest) dateRanges(raw RawQuery, opts []RawQueryOption) ([]Condition, error) {
+	query, err := SelectRequest{
+		Object: r.Object,
+		Fields: []string{"MIN(CreatedDate) oldest", "MAX(CreatedDate) newest"},
+		Where:  r.Where,
+	}.SOQL()
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := raw(query, opts...)
+	if err != nil {
+		return nil, err
+	}
+	aggregates := res.Aggregates()
+	if len(aggregates) == 0 {
+		return nil, nil
+	}
+	oldest, newest := aggregates[0].Time("oldest"), aggregates[0].Time("newest")
+	if oldest.IsZero() {
+		return nil, nil
+	}
+
+	step := newest.Sub(oldest) / time.Duration(r.Chunks)
+	if step < time.Second {
+		return []Condition{nil}, nil
+	}
+
+	ranges := make([]Condition, 0, r.Chunks)
+	for i := 0; i < r.Chunks; i++ {
+		var conds []Condition
+		if i > 0 {
+			conds = append(conds, Ge(chunkByCreatedDate, oldest.Add(step*time.Duration(i)).Truncate(time.Second)))
+		}
+		if i < r.Chunks-1 {
+			conds = append(conds, Lt(chunkByCreatedDate, oldest.Add(step*time.Duration(i+1)).Truncate(time.Second)))
+		}
+		ranges = append(ranges, rangeCondition(conds))
+	}
+	return ranges, nil
+}
+
+// rangeCondition combines the bounds of a range, which may be none at all for a single range.
+func rangeCondition(conds []Condition) Condition {
+	if len(conds) == 0 {
+		return nil
+	}
+	return And(conds...)
+}
+
+// idAlphabet orders the characters of salesforce Ids like their byte values.
+const idAlphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// idPrefixLength is the length of the key prefix identifying the object type of an Id.
+const idPrefixLength = 3
+
+// splitIDs returns up to chunks-1 distinct Ids evenly spaced between lowest and highest. Ids are compared by their
+// case-sensitive 15 character form, whose last 12 characters are read as a base 62 number. The spacing is even in
+// that number, not in the records, which idRanges makes up for by splitting where the records are.
+func splitIDs(lowest, highest string, chunks int) ([]string, error) {
+	if len(lowest) < 15 || len(highest) < 15 {
+		return nil, errors.New("ids must have at least 15 characters")
+	}
+	lowest, highest = lowest[:15], highest[:15]
+	if lowest[:idPrefixLength] != highest[:idPrefixLength] {
+		return nil, fmt.Errorf("ids %v and %v belong to different objects", lowest, highest)
+	}
+
+	lo, err := decodeID(lowest[idPrefixLength:])
+	if err != nil {
+		return nil, err
+	}
+	hi, err := decodeID(highest[idPrefixLength:])
+	if err != nil {
+		return nil, err
+	}
+
+	width := new(big.Int).Sub(hi, lo)
+	var bounds []string
+	for i := 1; i < chunks; i++ {
+		offset := new(big.Int).Mul(width, big.NewInt(int64(i)))
+		offset.Div(offset, big.NewInt(int64(chunks)))
+		bound := lowest[:idPrefixLength] + encodeID(offset.Add(offset, lo), 15-idPrefixLength)
+		if bound <= lowest || (len(bounds) > 0 && bound == bounds[len(bounds)-1]) {
+			continue
+		}
+		bounds = append(bounds, bound)
+	}
+	return bounds, nil
+}
+
+func decodeID(s string) (*big.Int, error) {
+	n := new(big.Int)
+	base := big.NewInt(int64(len(idAlphabet)))
+	for _, c := range s {
+		digit := strings.IndexRune(idAlphabet, c)
+		if digit < 0 {
+			return nil, fmt.Errorf("invalid character %q in id", c)
+		}
+		n.Mul(n, base)
+		n.Add(n, big.NewInt(int64(digit)))
+	}
+	return n, nil
+}
+
+func encodeID(n *big.Int, length int) string {
+	digits := make([]byte, length)
+	base := big.NewInt(int64(len(idAlphabet)))
+	n = new(big.Int).Set(n)
+	digit := new(big.Int)
+	for i := length - 1; i >= 0; i-- {
+		n.DivMod(n, base, digit)
+		digits[i] = idAlphabet[digit.Int64()]
+	}
+	return string(digits)
+}
+
+// WithContext sets the request context. Cancelling it stops all running ranges.
+func (f Chunked) WithContext(v context.Context) ChunkedOption {
+	return func(r *ChunkedRequest) error {
+		r.ctx = v
+		return nil
+	}
+}
+
+// Fields sets the fields to select, Id by default.
+func (f Chunked) Fields(fields ...string) ChunkedOption {
+	return func(r *ChunkedRequest) error {
+		if len(fields) == 0 {
+			return errors.New("at least one field is required")
+		}
+		r.Fields = fields
+		return nil
+	}
+}
+
+// Where filters the records by condition.
+func (f Chunked) Where(condition Condition) ChunkedOption {
+	return func(r *ChunkedRequest) error {
+		r.Where = condition
+		return nil
+	}
+}
+
+// Chunks sets the number of ranges the query is split into, 4 by default. Id ranges stay fewer when the records
+// cannot be divided any further.
+func (f Chunked) Chunks(chunks int) ChunkedOption {
+	return func(r *ChunkedRequest) error {
+		if chunks < 1 {
+			return errors.New("chunks must be at least 1")
+		}
+		r.Chunks = chunks
+		return nil
+	}
+}
+
+// Workers bounds the number of ranges queried at the same time, one per chunk by default.
+func (f Chunked) Workers(workers int) ChunkedOption {
+	return func(r *ChunkedRequest) error {
+		if workers < 1 {
+			return errors.New("workers must be at least 1")
+		}
+		r.Workers = workers
+		return nil
+	}
+}
+
+// ByCreatedDate splits the query into CreatedDate windows of equal length instead of Id ranges, which suits objects
+// whose records were created steadily over time.
+func (f Chunked) ByCreatedDate() ChunkedOption {
+	return func(r *ChunkedRequest) error {
+		r.By = chunkByCreatedDate
+		return nil
+	}
+}
+
+// QueryAll includes deleted and archived records.
+func (f Chunked) QueryAll() ChunkedOption {
+	return func(r *ChunkedRequest) error {
+		r.QueryAll = true
+		return nil
+	}
+}
@@ -0,0 +1,241 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/0xArch3r/goforce/types"
+)
+
+func TestIDRoundTrip(t *testing.T) {
+	for _, id := range []string{"000000000000", "000000000001", "3000000Abz9Z", "5g00000XyZ01", "zzzzzzzzzzzz"} {
+		n, err := decodeID(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := encodeID(n, len(id)); got != id {
+			t.Errorf("encodeID(decodeID(%v)) = %v", id, got)
+		}
+	}
+
+	n, err := decodeID("zz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n.Cmp(big.NewInt(62*62-1)) != 0 {
+		t.Errorf("decodeID(zz) = %v, want %v", n, 62*62-1)
+	}
+	if _, err := decodeID("00-"); err == nil {
+		t.Error("want an error for invalid characters")
+	}
+}
+
+func TestSplitIDs(t *testing.T) {
+	tests := []struct {
+		name    string
+		lowest  string
+		highest string
+		chunks  int
+		want    []string
+		err     bool
+	}{
+		{"even", "001000000000000", "001000000000040", 4, []string{"001000000000010", "001000000000020", "001000000000030"}, false},
+		{"18 characters", "001000000000000AAA", "001000000000040AAA", 2, []string{"001000000000020"}, false},
+		{"carries into the next digit", "00100000000000z", "001000000000011", 2, []string{"001000000000010"}, false},
+		{"adjacent", "001000000000000", "001000000000001", 4, nil, false},
+		{"equal", "001000000000005", "001000000000005", 4, nil, false},
+		{"bounds stay distinct", "001000000000000", "001000000000003", 8, []string{"001000000000001", "001000000000002"}, false},
+		{"different objects", "001000000000000", "003000000000040", 2, nil, true},
+		{"too short", "001", "001000000000040", 2, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := splitIDs(tt.lowest, tt.highest, tt.chunks)
+			if (err != nil) != tt.err {
+				t.Fatalf("err = %v, want error %v", err, tt.err)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("splitIDs = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// idTable answers the queries Chunked sends for Id ranges from a sorted list of Ids.
+type idTable struct {
+	ids     []string
+	queries int
+}
+
+var idBound = regexp.MustCompile(`Id (>=|<=|<) '(\w+)'`)
+
+func (tbl *idTable) Perform(req *http.Request) (*Response, error) {
+	tbl.queries++
+	query := req.URL.Query().Get("q")
+
+	var matched []string
+	for _, id := range tbl.ids {
+		ok := true
+		for _, m := range idBound.FindAllStringSubmatch(query, -1) {
+			switch m[1] {
+			case ">=":
+				ok = ok && id >= m[2]
+			case "<=":
+				ok = ok && id <= m[2]
+			case "<":
+				ok = ok && id < m[2]
+			}
+		}
+		if ok {
+			matched = append(matched, id)
+		}
+	}
+
+	res := types.QueryResult{Done: true, TotalSize: len(matched)}
+	switch {
+	case strings.Contains(query, "COUNT()"):
+	case strings.Contains(query, "ORDER BY Id DESC"):
+		if len(matched) > 0 {
+			res.Records = []types.SObject{{"Id": matched[len(matched)-1]}}
+		}
+	case strings.Contains(query, "ORDER BY Id ASC"):
+		if len(matched) > 0 {
+			res.Records = []types.SObject{{"Id": matched[0]}}
+		}
+	default:
+		for _, id := range matched {
+			res.Records = append(res.Records, types.SObject{"Id": id})
+		}
+	}
+
+	body, err := json.Marshal(res)
+	if err != nil {
+		return nil, err
+	}
+	return &Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(string(body))), Header: http.Header{}}, nil
+}
+
+func TestChunkedIDRangesFollowTheData(t *testing.T) {
+	// A few records from before the org moved to another instance and most records after it, which puts nearly all
+	// of them into a tiny part of the Id space.
+	tbl := &idTable{}
+	for i := 0; i < 10; i++ {
+		tbl.ids = append(tbl.ids, fmt.Sprintf("0013000000%05d", i))
+	}
+	for i := 0; i < 990; i++ {
+		tbl.ids = append(tbl.ids, fmt.Sprintf("0015g00000%05d", i))
+	}
+	sort.Strings(tbl.ids)
+
+	a := New(tbl)
+	seen := map[string]bool{}
+	ranges := a.Query.Chunked("Account", a.Query.Chunked.Chunks(8), a.Query.Chunked.Workers(1))
+
+	r := ChunkedRequest{Object: "Account", Fields: []string{"Id"}, Chunks: 8, By: chunkByID}
+	conds, err := r.idRanges(a.Query.Raw, a.Query.Count, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(conds) != 8 {
+		t.Fatalf("got %d ranges, want 8", len(conds))
+	}
+	for _, cond := range conds {
+		query, err := SelectRequest{Object: "Account", Fields: []string{"Id"}, Where: cond}.SOQL()
+		if err != nil {
+			t.Fatal(err)
+		}
+		res, err := a.Query.Raw(query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(res.Records) > 250 {
+			t.Errorf("range %v holds %d of 1000 records", query, len(res.Records))
+		}
+	}
+
+	for record, err := range ranges {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if seen[record.ID()] {
+			t.Errorf("record %v yielded twice", record.ID())
+		}
+		seen[record.ID()] = true
+	}
+	if len(seen) != len(tbl.ids) {
+		t.Errorf("yielded %d records, want %d", len(seen), len(tbl.ids))
+	}
+}
+
+func TestChunkedDateRanges(t *testing.T) {
+	oldest := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	newest := oldest.Add(4 * 24 * time.Hour)
+
+	tests := []struct {
+		name   string
+		body   string
+		chunks int
+		want   []string
+	}{
+		{
+			name:   "windows",
+			body:   fmt.Sprintf(`{"done":true,"totalSize":1,"records":[{"attributes":{"type":"AggregateResult"},"oldest":%q,"newest":%q}]}`, oldest.Format("2006-01-02T15:04:05.000+0000"), newest.Format("2006-01-02T15:04:05.000+0000")),
+			chunks: 4,
+			want: []string{
+				"CreatedDate < 2024-01-02T00:00:00Z",
+				"(CreatedDate >= 2024-01-02T00:00:00Z) AND (CreatedDate < 2024-01-03T00:00:00Z)",
+				"(CreatedDate >= 2024-01-03T00:00:00Z) AND (CreatedDate < 2024-01-04T00:00:00Z)",
+				"CreatedDate >= 2024-01-04T00:00:00Z",
+			},
+		},
+		{
+			name:   "single instant",
+			body:   fmt.Sprintf(`{"done":true,"totalSize":1,"records":[{"attributes":{"type":"AggregateResult"},"oldest":%q,"newest":%q}]}`, oldest.Format("2006-01-02T15:04:05.000+0000"), oldest.Format("2006-01-02T15:04:05.000+0000")),
+			chunks: 4,
+			want:   []string{""},
+		},
+		{
+			name:   "no records",
+			body:   `{"done":true,"totalSize":1,"records":[{"attributes":{"type":"AggregateResult"},"oldest":null,"newest":null}]}`,
+			chunks: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := TransportFunc(func(req *http.Request) (*Response, error) {
+				return &Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(tt.body)), Header: http.Header{}}, nil
+			})
+			r := ChunkedRequest{Object: "Task", Chunks: tt.chunks, By: chunkByCreatedDate}
+			conds, err := r.dateRanges(newRawQueryFunc(transport), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []string
+			for _, cond := range conds {
+				if cond == nil {
+					got = append(got, "")
+					continue
+				}
+				soql, err := cond.SOQL()
+				if err != nil {
+					t.Fatal(err)
+				}
+				got = append(got, soql)
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("got\n%v\nwant\n%v", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package api
+
+import "fmt"
+
+// Count returns the number of records of object matching where, which may be nil to count all records. It accepts
+// the same options as RawQuery.
+type Count func(object string, where Condition, opts ...RawQueryOption) (int, error)
+
+func newCountFunc(base Transport) Count {
+	raw := newRawQueryFunc(base)
+
+	return func(object string, where Condition, opts ...RawQueryOption) (int, error) {
+		query := fmt.Sprintf("SELECT COUNT() FROM %v", object)
+		if where != nil {
+			filter, err := where.SOQL()
+			if err != nil {
+				return 0, err
+			}
+			query = fmt.Sprintf("%v WHERE %v", query, filter)
+		}
+
+		// COUNT() returns no records, only the size of the result.
+		res, err := raw(query, opts...)
+		if err != nil {
+			return 0, err
+		}
+		return res.TotalSize, nil
+	}
+}
@@ -0,0 +1,107 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/0xArch3r/goforce/types"
+)
+
+func newCreateFunc(b Transport) Create {
+	return func(object string, fields types.SObject, o ...CreateOption) (*types.SaveResult, error) {
+		if object == "" {
+			object = fields.Type()
+		}
+		r := CreateRequest{Object: object, Fields: fields}
+		for _, f := range o {
+			err := f(&r)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if r.Object == "" {
+			return nil, errors.New("object type is required")
+		}
+
+		resp, err := r.Do(r.ctx, b)
+		if err != nil {
+			return nil, err
+		}
+		res := &types.SaveResult{}
+		err = resp.decode(res)
+		if err != nil {
+			return nil, err
+		}
+		return res, nil
+	}
+}
+
+// Create inserts a new record. When object is empty, the type is taken from the attributes of fields.
+type Create func(object string, fields types.SObject, o ...CreateOption) (*types.SaveResult, error)
+
+type CreateOption func(*CreateRequest) error
+
+// CreateRequest configures the Create API request.
+type CreateRequest struct {
+	Object  string
+	Fields  types.SObject
+	Tooling bool
+
+	ctx context.Context
+}
+
+// Do executes the request and returns response or error.
+func (r CreateRequest) Do(ctx context.Context, transport Transport) (*Response, error) {
+	method := http.MethodPost
+	path := fmt.Sprintf("/sobjects/%v/", r.Object)
+	if r.Tooling {
+		path = "/tooling" + path
+	}
+
+	// The Id is assigned by salesforce and is rejected on insert.
+	fields := r.Fields.Payload()
+	delete(fields, "Id")
+
+	payload, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(method, path, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	} else {
+		req = req.WithContext(context.Background())
+	}
+
+	res, err := transport.Perform(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// WithContext sets the request context.
+func (f Create) WithContext(v context.Context) CreateOption {
+	return func(r *CreateRequest) error {
+		r.ctx = v
+		return nil
+	}
+}
+
+// Tooling sends the request to the Tooling API.
+func (f Create) Tooling() CreateOption {
+	return func(r *CreateRequest) error {
+		r.Tooling = true
+		return nil
+	}
+}
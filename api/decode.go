@@ -0,0 +1,31 @@
+package api
+
+// QueryAs runs a raw query through q.Iter, following every batch, and decodes the records into T. T is usually a
+// struct whose fields carry sf tags; see types.SObject.Decode.
+//
+//	type Contact struct {
+//		ID      string    `sf:"Id"`
+//		Name    string    `sf:"Name"`
+//		Created time.Time `sf:"CreatedDate"`
+//		Account struct {
+//			Name string `sf:"Name"`
+//		} `sf:"Account"`
+//	}
+//
+//	contacts, err := api.QueryAs[Contact](client.Query, "SELECT Id, Name, CreatedDate, Account.Name FROM Contact")
+func QueryAs[T any](q *Query, query string, opts ...RawQueryOption) ([]T, error) {
+	var res []T
+	for record, err := range q.Iter(query, opts...) {
+		if err != nil {
+			return nil, err
+		}
+
+		var item T
+		err = record.Decode(&item)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, item)
+	}
+	return res, nil
+}
@@ -0,0 +1,82 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+func newDeleteFunc(b Transport) Delete {
+	return func(object string, id string, o ...DeleteOption) error {
+		r := DeleteRequest{Object: object, ID: id}
+		for _, f := range o {
+			err := f(&r)
+			if err != nil {
+				return err
+			}
+		}
+
+		resp, err := r.Do(r.ctx, b)
+		if err != nil {
+			return err
+		}
+		return resp.decode(nil)
+	}
+}
+
+// Delete removes a record by Type & Id.
+type Delete func(object string, id string, o ...DeleteOption) error
+
+type DeleteOption func(*DeleteRequest) error
+
+// DeleteRequest configures the Delete API request.
+type DeleteRequest struct {
+	Object  string
+	ID      string
+	Tooling bool
+
+	ctx context.Context
+}
+
+// Do executes the request and returns response or error.
+func (r DeleteRequest) Do(ctx context.Context, transport Transport) (*Response, error) {
+	method := http.MethodDelete
+	path := fmt.Sprintf("/sobjects/%v/%v", r.Object, r.ID)
+	if r.Tooling {
+		path = "/tooling" + path
+	}
+
+	req, err := http.NewRequest(method, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	} else {
+		req = req.WithContext(context.Background())
+	}
+
+	res, err := transport.Perform(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// WithContext sets the request context.
+func (f Delete) WithContext(v context.Context) DeleteOption {
+	return func(r *DeleteRequest) error {
+		r.ctx = v
+		return nil
+	}
+}
+
+// Tooling sends the request to the Tooling API.
+func (f Delete) Tooling() DeleteOption {
+	return func(r *DeleteRequest) error {
+		r.Tooling = true
+		return nil
+	}
+}
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/0xArch3r/goforce/types"
+)
+
+// Explain returns the query plans for a query without running it. Besides SOQL it accepts the Id of a report or
+// list view.
+type Explain func(query string, opts ...ExplainOption) (*types.ExplainResult, error)
+
+func newExplainFunc(base Transport) Explain {
+	return func(query string, opts ...ExplainOption) (*types.ExplainResult, error) {
+		r := ExplainRequest{
+			Query: query,
+		}
+		for _, f := range opts {
+			err := f(&r)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := r.Do(r.ctx, base)
+		if err != nil {
+			return nil, err
+		}
+
+		res := &types.ExplainResult{}
+		err = resp.decode(res)
+		if err != nil {
+			return nil, err
+		}
+		return res, nil
+	}
+}
+
+type ExplainOption func(*ExplainRequest) error
+
+type ExplainRequest struct {
+	ctx     context.Context
+	Query   string
+	Tooling bool
+}
+
+func (r ExplainRequest) Do(ctx context.Context, transport Transport) (*Response, error) {
+	method := http.MethodGet
+
+	path := fmt.Sprintf("/query?explain=%s", url.QueryEscape(r.Query))
+	if r.Tooling {
+		path = "/tooling" + path
+	}
+
+	req, err := http.NewRequest(method, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	} else {
+		req = req.WithContext(context.Background())
+	}
+
+	res, err := transport.Perform(req)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// WithContext sets the request context.
+func (f Explain) WithContext(v context.Context) ExplainOption {
+	return func(r *ExplainRequest) error {
+		r.ctx = v
+		return nil
+	}
+}
+
+// Tooling explains the query against the Tooling API.
+func (f Explain) Tooling() ExplainOption {
+	return func(r *ExplainRequest) error {
+		r.Tooling = true
+		return nil
+	}
+}
@@ -2,9 +2,7 @@ package api
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 
 	"github.com/0xArch3r/goforce/types"
@@ -25,13 +23,8 @@ func newGetFunc(b Transport) Get {
 			return nil, err
 		}
 
-		data, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
-
 		obj := &types.SObject{}
-		err = json.Unmarshal(data, obj)
+		err = resp.decode(obj)
 		if err != nil {
 			return nil, err
 		}
@@ -45,8 +38,9 @@ type GetOption func(*GetRequest) error
 
 // GetRequest configures the Get API request.
 type GetRequest struct {
-	Object string
-	ID     string
+	Object  string
+	ID      string
+	Tooling bool
 
 	ctx context.Context
 }
@@ -62,6 +56,9 @@ func (r GetRequest) Do(ctx context.Context, transport Transport) (*Response, err
 	method = http.MethodGet
 
 	path = fmt.Sprintf("/sobjects/%v/%v", r.Object, r.ID)
+	if r.Tooling {
+		path = "/tooling" + path
+	}
 
 	//params = make(map[string]string)
 
@@ -91,3 +88,11 @@ func (f Get) WithContext(v context.Context) GetOption {
 		return nil
 	}
 }
+
+// Tooling sends the request to the Tooling API.
+func (f Get) Tooling() GetOption {
+	return func(r *GetRequest) error {
+		r.Tooling = true
+		return nil
+	}
+}
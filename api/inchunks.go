@@ -0,0 +1,249 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"net/url"
+	"reflect"
+	"strings"
+
+	"github.com/0xArch3r/goforce/types"
+)
+
+// InChunks runs a query whose :name placeholder takes a list too long for a single request, e.g. thousands of Ids.
+// The values are split into chunks that keep every query within MaxLength once URL-encoded, and the chunks run one
+// after the other or, with Parallel, concurrently. Duplicate values are dropped, and records sharing an Id are
+// yielded once. Records arrive in no particular order.
+//
+//	for record, err := range client.Query.InChunks(
+//		"SELECT Id, Name FROM Contact WHERE AccountId IN :ids",
+//		"ids", accountIDs,
+//		client.Query.InChunks.Parallel(4),
+//	) {
+//		...
+//	}
+type InChunks func(query string, name string, values interface{}, opts ...InChunksOption) iter.Seq2[types.SObject, error]
+
+type InChunksOption func(*InChunksRequest) error
+
+// InChunksRequest configures an InChunks query.
+type InChunksRequest struct {
+	ctx       context.Context
+	Query     string
+	Name      string
+	Values    interface{}
+	Parallel  int
+	MaxLength int
+	Raw       []RawQueryOption
+}
+
+// DefaultInChunksMaxLength keeps the request line well below the 16,384 characters salesforce accepts, leaving room
+// for the instance URL and the resource path.
+const DefaultInChunksMaxLength = 15000
+
+func newInChunksFunc(base Transport) InChunks {
+	records := newIterFunc(base)
+
+	return func(query string, name string, values interface{}, opts ...InChunksOption) iter.Seq2[types.SObject, error] {
+		return func(yield func(types.SObject, error) bool) {
+			r := InChunksRequest{
+				Query:     query,
+				Name:      name,
+				Values:    values,
+				Parallel:  1,
+				MaxLength: DefaultInChunksMaxLength,
+			}
+			for _, f := range opts {
+				err := f(&r)
+				if err != nil {
+					yield(nil, err)
+					return
+				}
+			}
+
+			template := RawQueryRequest{Query: r.Query}
+			for _, f := range r.Raw {
+				err := f(&template)
+				if err != nil {
+					yield(nil, err)
+					return
+				}
+			}
+
+			queries, err := r.queries(template)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if len(queries) == 0 {
+				return
+			}
+
+			// The queries are bound already, only the way they are sent is taken over from the template.
+			settings := func(q *RawQueryRequest) error {
+				q.QueryAll = template.QueryAll
+				q.Tooling = template.Tooling
+				q.BatchSize = template.BatchSize
+				q.Prefetch = template.Prefetch
+				return nil
+			}
+
+			ctx := r.ctx
+			if ctx == nil {
+				ctx = template.ctx
+			}
+
+			seen := map[string]bool{}
+			parallel(ctx, queries, r.Parallel, func(ctx context.Context, query string) iter.Seq2[types.SObject, error] {
+				return records(query, settings, func(q *RawQueryRequest) error {
+					q.ctx = ctx
+					return nil
+				})
+			}, func(record types.SObject, err error) bool {
+				if err == nil {
+					id := record.ID()
+					if id != "" && seen[id] {
+						return true
+					}
+					seen[id] = true
+				}
+				return yield(record, err)
+			})
+		}
+	}
+}
+
+// queries binds the values to r.Name in as few queries as r.MaxLength allows.
+func (r InChunksRequest) queries(template RawQueryRequest) ([]string, error) {
+	if r.Name == "" {
+		return nil, errors.New("bind name cannot be empty")
+	}
+	if r.MaxLength <= 0 {
+		return nil, errors.New("max length must be positive")
+	}
+
+	rv := reflect.ValueOf(r.Values)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, fmt.Errorf("cannot split %T into chunks, need a slice", r.Values)
+	}
+
+	var literals []string
+	unique := map[string]bool{}
+	for i := 0; i < rv.Len(); i++ {
+		literal, err := FormatLiteral(rv.Index(i).Interface())
+		if err != nil {
+			return nil, err
+		}
+		if unique[literal] {
+			continue
+		}
+		unique[literal] = true
+		literals = append(literals, literal)
+	}
+	if len(literals) == 0 {
+		return nil, nil
+	}
+
+	bound := func(list string) (string, error) {
+		q := template
+		q.Named = map[string]interface{}{}
+		for k, v := range template.Named {
+			q.Named[k] = v
+		}
+		q.Named[r.Name] = Literal("(" + list + ")")
+		return q.SOQL()
+	}
+
+	skeleton, err := bound("")
+	if err != nil {
+		return nil, err
+	}
+	// Unused binds are ignored, which would send the same query once per chunk.
+	probe, err := bound("null")
+	if err != nil {
+		return nil, err
+	}
+	if probe == skeleton {
+		return nil, fmt.Errorf("query has no :%v placeholder for the values", r.Name)
+	}
+	// Query escaping works per character, so the lengths of the escaped parts add up.
+	budget := r.MaxLength - len(url.QueryEscape(skeleton))
+	separator := len(url.QueryEscape(", "))
+
+	var queries []string
+	var chunk []string
+	used := 0
+	flush := func() error {
+		query, err := bound(strings.Join(chunk, ", "))
+		if err != nil {
+			return err
+		}
+		queries = append(queries, query)
+		chunk, used = nil, 0
+		return nil
+	}
+
+	for _, literal := range literals {
+		size := len(url.QueryEscape(literal))
+		if len(chunk) > 0 {
+			size += separator
+		}
+		if used+size > budget && len(chunk) > 0 {
+			err := flush()
+			if err != nil {
+				return nil, err
+			}
+			size -= separator
+		}
+		if size > budget {
+			return nil, fmt.Errorf("value %v does not fit into a query of %d characters", literal, r.MaxLength)
+		}
+		chunk = append(chunk, literal)
+		used += size
+	}
+	err = flush()
+	if err != nil {
+		return nil, err
+	}
+	return queries, nil
+}
+
+// WithContext sets the request context.
+func (f InChunks) WithContext(v context.Context) InChunksOption {
+	return func(r *InChunksRequest) error {
+		r.ctx = v
+		return nil
+	}
+}
+
+// Parallel runs up to n chunks at the same time.
+func (f InChunks) Parallel(n int) InChunksOption {
+	return func(r *InChunksRequest) error {
+		if n < 1 {
+			return errors.New("parallel must be at least 1")
+		}
+		r.Parallel = n
+		return nil
+	}
+}
+
+// MaxLength caps each URL-encoded query at n characters instead of DefaultInChunksMaxLength.
+func (f InChunks) MaxLength(n int) InChunksOption {
+	return func(r *InChunksRequest) error {
+		if n < 1 {
+			return errors.New("max length must be positive")
+		}
+		r.MaxLength = n
+		return nil
+	}
+}
+
+// With passes options of Query.Raw on to every chunk, e.g. further binds, QueryAll or BatchSize.
+func (f InChunks) With(opts ...RawQueryOption) InChunksOption {
+	return func(r *InChunksRequest) error {
+		r.Raw = append(r.Raw, opts...)
+		return nil
+	}
+}
@@ -0,0 +1,106 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestInChunks(t *testing.T) {
+	var mu sync.Mutex
+	var queries []string
+	transport := TransportFunc(func(req *http.Request) (*Response, error) {
+		if req.URL.Path != "/queryAll" {
+			return nil, fmt.Errorf("unexpected request %v", req.URL)
+		}
+		query := req.URL.Query().Get("q")
+		mu.Lock()
+		queries = append(queries, query)
+		mu.Unlock()
+
+		// Every chunk returns the shared record 0 besides a record of its own.
+		body := fmt.Sprintf(`{"done":true,"totalSize":2,"records":[{"Id":"0"},{"Id":"%d"}]}`, len(query))
+		return &Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body)), Header: http.Header{}}, nil
+	})
+
+	var ids []string
+	for i := 0; i < 100; i++ {
+		ids = append(ids, fmt.Sprintf("001%015d", i%60))
+	}
+
+	a := New(transport)
+	seen := map[string]int{}
+	for record, err := range a.Query.InChunks(
+		"SELECT Id FROM Contact WHERE AccountId IN :ids AND LastName = :name",
+		"ids", ids,
+		a.Query.InChunks.Parallel(3),
+		a.Query.InChunks.MaxLength(300),
+		a.Query.InChunks.With(Named("name", "O'Brien"), a.Query.Raw.QueryAll()),
+	) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		seen[record.ID()]++
+	}
+
+	if seen["0"] != 1 {
+		t.Errorf("shared record yielded %d times, want once", seen["0"])
+	}
+	if len(queries) < 2 {
+		t.Fatalf("sent %d queries, want the values split", len(queries))
+	}
+
+	var bound []string
+	for _, query := range queries {
+		if n := len(url.QueryEscape(query)); n > 300 {
+			t.Errorf("query of %d characters exceeds the maximum: %v", n, query)
+		}
+		if !strings.HasSuffix(query, `) AND LastName = 'O\'Brien'`) {
+			t.Errorf("query lost the other bind: %v", query)
+		}
+		list := query[strings.Index(query, "(")+1 : strings.LastIndex(query, ")")]
+		bound = append(bound, strings.Split(list, ", ")...)
+	}
+	sort.Strings(bound)
+	if len(bound) != 60 || bound[0] != "'001000000000000000'" || bound[59] != "'001000000000000059'" {
+		t.Errorf("bound %d values, want the 60 distinct ones once each", len(bound))
+	}
+}
+
+func TestInChunksRequiresPlaceholder(t *testing.T) {
+	a := New(TransportFunc(func(req *http.Request) (*Response, error) {
+		t.Error("no request expected")
+		return nil, errors.New("no request expected")
+	}))
+
+	for _, query := range []string{
+		"SELECT Id FROM Contact WHERE AccountId IN ('001000000000001')",
+		"SELECT Id FROM Contact WHERE Title = ':ids'",
+	} {
+		for _, err := range a.Query.InChunks(query, "ids", []string{"001000000000001"}) {
+			if err == nil || !strings.Contains(err.Error(), "no :ids placeholder") {
+				t.Errorf("%v: err = %v, want a missing placeholder error", query, err)
+			}
+		}
+	}
+}
+
+func TestInChunksValueTooLong(t *testing.T) {
+	a := New(TransportFunc(func(req *http.Request) (*Response, error) {
+		t.Error("no request expected")
+		return nil, errors.New("no request expected")
+	}))
+
+	for _, err := range a.Query.InChunks("SELECT Id FROM Contact WHERE Id IN :ids", "ids",
+		[]string{strings.Repeat("x", 100)}, a.Query.InChunks.MaxLength(80)) {
+		if err == nil || !strings.Contains(err.Error(), "does not fit") {
+			t.Errorf("err = %v, want a value too long error", err)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"iter"
+
+	"github.com/0xArch3r/goforce/types"
+)
+
+// Iter runs a raw query and yields every record of the result, following NextRecordsURL until salesforce reports
+// the result as done. Records are decoded while the response is read, keeping memory bounded for large results. It
+// accepts the same options as RawQuery.
+//
+//	for record, err := range client.Query.Iter("SELECT Id FROM Contact") {
+//		...
+//	}
+type Iter func(query string, opts ...RawQueryOption) iter.Seq2[types.SObject, error]
+
+func newIterFunc(base Transport) Iter {
+	return func(query string, opts ...RawQueryOption) iter.Seq2[types.SObject, error] {
+		return func(yield func(types.SObject, error) bool) {
+			r := RawQueryRequest{Query: query}
+			for _, f := range opts {
+				err := f(&r)
+				if err != nil {
+					yield(nil, err)
+					return
+				}
+			}
+
+			if r.Prefetch > 0 {
+				prefetch(r, base, yield)
+				return
+			}
+
+			resp, err := r.Do(r.ctx, base)
+			for {
+				if err != nil {
+					yield(nil, err)
+					return
+				}
+
+				// Records are decoded one at a time while the batch is read, so wide records don't pile up in memory.
+				batch := types.QueryResult{}
+				stopped := false
+				err = resp.stream("records", &batch, func(record types.SObject) bool {
+					stopped = !yield(record, nil)
+					return !stopped
+				})
+				if stopped {
+					return
+				}
+				if err != nil {
+					yield(nil, err)
+					return
+				}
+
+				if batch.Done || batch.NextRecordsURL == "" {
+					return
+				}
+				resp, err = MoreRequest{URL: batch.NextRecordsURL}.Do(r.ctx, base)
+			}
+		}
+	}
+}
+
+// SelectIter runs a structured query like Select and yields every record of the result, following NextRecordsURL
+// until salesforce reports the result as done. It accepts the same options as Select, except Into.
+//
+//	for record, err := range client.Query.SelectIter("Contact",
+//		client.Query.Select.Fields("Id", "Name"),
+//		client.Query.Select.Where(api.Eq("LeadSource", "Web")),
+//	) {
+//		...
+//	}
+type SelectIter func(object string, opts ...SelectOption) iter.Seq2[types.SObject, error]
+
+func newSelectIterFunc(base Transport) SelectIter {
+	records := newIterFunc(base)
+
+	return func(object string, opts ...SelectOption) iter.Seq2[types.SObject, error] {
+		return func(yield func(types.SObject, error) bool) {
+			r := SelectRequest{
+				Object: object,
+				Fields: []string{"FIELDS(ALL)"},
+			}
+			for _, f := range opts {
+				err := f(&r)
+				if err != nil {
+					yield(nil, err)
+					return
+				}
+			}
+			if r.into != nil {
+				yield(nil, errors.New("into cannot be used with SelectIter, decode the records one at a time instead"))
+				return
+			}
+
+			query, err := r.SOQL()
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			for record, err := range records(query, func(q *RawQueryRequest) error {
+				q.ctx = r.ctx
+				q.QueryAll = r.QueryAll
+				q.Tooling = r.Tooling
+				q.BatchSize = r.BatchSize
+				q.Prefetch = r.Prefetch
+				return nil
+			}) {
+				if !yield(record, err) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// prefetch yields the records of the query r while a goroutine fetches up to r.Prefetch batches ahead. The
+// goroutine is cancelled through the request context as soon as the caller stops iterating.
+func prefetch(r RawQueryRequest, base Transport, yield func(types.SObject, error) bool) {
+	parent := r.ctx
+	if parent == nil {
+		parent = context.Background()
+	}
+	ctx, cancel := context.WithCancel(parent)
+	defer cancel()
+
+	type batch struct {
+		res *types.QueryResult
+		err error
+	}
+	batches := make(chan batch, r.Prefetch)
+
+	go func() {
+		defer close(batches)
+
+		resp, err := r.Do(ctx, base)
+		for {
+			res := &types.QueryResult{}
+			if err == nil {
+				err = resp.decode(res)
+			}
+
+			select {
+			case batches <- batch{res, err}:
+			case <-ctx.Done():
+				return
+			}
+
+			if err != nil || res.Done || res.NextRecordsURL == "" {
+				return
+			}
+			resp, err = MoreRequest{URL: res.NextRecordsURL}.Do(ctx, base)
+		}
+	}()
+
+	// Stop the fetcher and wait for it before returning, whichever way iteration ends.
+	defer func() {
+		cancel()
+		for range batches {
+		}
+	}()
+
+	for b := range batches {
+		if b.err != nil {
+			yield(nil, b.err)
+			return
+		}
+		for _, record := range b.res.Records {
+			if !yield(record, nil) {
+				return
+			}
+		}
+	}
+
+	// The fetcher only gives up silently when the caller's context is done.
+	if err := parent.Err(); err != nil {
+		yield(nil, err)
+	}
+}
@@ -0,0 +1,113 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSelectIterFollowsNextRecordsURL(t *testing.T) {
+	var queries []string
+	transport := TransportFunc(func(req *http.Request) (*Response, error) {
+		var body string
+		switch {
+		case req.URL.Path == "/queryAll":
+			queries = append(queries, req.URL.Query().Get("q"))
+			body = `{"done":false,"totalSize":3,"nextRecordsUrl":"/services/data/v54.0/query/01g-2","records":[{"Id":"1"},{"Id":"2"}]}`
+		case req.URL.Path == "/services/data/v54.0/query/01g-2":
+			body = `{"done":true,"totalSize":3,"records":[{"Id":"3"}]}`
+		default:
+			return nil, fmt.Errorf("unexpected request %v", req.URL)
+		}
+		return &Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body)), Header: http.Header{}}, nil
+	})
+
+	a := New(transport)
+	var ids []string
+	for record, err := range a.Query.SelectIter("Contact",
+		a.Query.Select.Fields("Id"),
+		a.Query.Select.Where(Eq("LastName", "O'Brien")),
+		a.Query.Select.QueryAll(),
+	) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, record.ID())
+	}
+
+	if got := strings.Join(ids, ","); got != "1,2,3" {
+		t.Errorf("ids = %v, want 1,2,3", got)
+	}
+	if want := `SELECT Id FROM Contact WHERE LastName = 'O\'Brien'`; len(queries) != 1 || queries[0] != want {
+		t.Errorf("queries = %v, want [%v]", queries, want)
+	}
+}
+
+func TestSelectIterRejectsInto(t *testing.T) {
+	a := New(TransportFunc(func(req *http.Request) (*Response, error) {
+		t.Fatal("no request expected")
+		return nil, nil
+	}))
+	var into []struct {
+		Id string `sf:"Id"`
+	}
+	for _, err := range a.Query.SelectIter("Contact", a.Query.Select.Into(&into)) {
+		if err == nil {
+			t.Error("want an error for Into")
+		}
+	}
+}
+
+func TestSelectIterPrefetch(t *testing.T) {
+	fetched := make(chan string, 3)
+	transport := TransportFunc(func(req *http.Request) (*Response, error) {
+		var body string
+		switch req.URL.Path {
+		case "/query":
+			fetched <- req.Header.Get("Sforce-Query-Options")
+			body = `{"done":false,"totalSize":3,"nextRecordsUrl":"/services/data/v54.0/query/01g-2","records":[{"Id":"1"}]}`
+		case "/services/data/v54.0/query/01g-2":
+			fetched <- "page 2"
+			body = `{"done":false,"totalSize":3,"nextRecordsUrl":"/services/data/v54.0/query/01g-3","records":[{"Id":"2"}]}`
+		case "/services/data/v54.0/query/01g-3":
+			fetched <- "page 3"
+			body = `{"done":true,"totalSize":3,"records":[{"Id":"3"}]}`
+		default:
+			return nil, fmt.Errorf("unexpected request %v", req.URL)
+		}
+		return &Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body)), Header: http.Header{}}, nil
+	})
+
+	a := New(transport)
+	var ids []string
+	for record, err := range a.Query.SelectIter("Contact",
+		a.Query.Select.Fields("Id"),
+		a.Query.Select.BatchSize(200),
+		a.Query.Select.Prefetch(2),
+	) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(ids) == 0 {
+			// The following batches are fetched while the first record is still being processed.
+			for _, want := range []string{"batchSize=200", "page 2", "page 3"} {
+				select {
+				case got := <-fetched:
+					if got != want {
+						t.Fatalf("fetched %v, want %v", got, want)
+					}
+				case <-time.After(time.Second):
+					t.Fatalf("%v was not prefetched", want)
+				}
+			}
+		}
+		ids = append(ids, record.ID())
+	}
+
+	if got := strings.Join(ids, ","); got != "1,2,3" {
+		t.Errorf("ids = %v, want 1,2,3", got)
+	}
+}
@@ -0,0 +1,74 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/0xArch3r/goforce/types"
+)
+
+func newLimitsFunc(b Transport) Limits {
+	return func(o ...LimitsOption) (types.Limits, error) {
+		r := LimitsRequest{}
+		for _, f := range o {
+			err := f(&r)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := r.Do(r.ctx, b)
+		if err != nil {
+			return nil, err
+		}
+
+		res := types.Limits{}
+		err = resp.decode(&res)
+		if err != nil {
+			return nil, err
+		}
+		return res, nil
+	}
+}
+
+// Limits lists the org limits and their remaining allocation.
+type Limits func(o ...LimitsOption) (types.Limits, error)
+
+type LimitsOption func(*LimitsRequest) error
+
+// LimitsRequest configures the Limits API request.
+type LimitsRequest struct {
+	ctx context.Context
+}
+
+// Do executes the request and returns response or error.
+func (r LimitsRequest) Do(ctx context.Context, transport Transport) (*Response, error) {
+	method := http.MethodGet
+	path := "/limits"
+
+	req, err := http.NewRequest(method, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	} else {
+		req = req.WithContext(context.Background())
+	}
+
+	res, err := transport.Perform(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// WithContext sets the request context.
+func (f Limits) WithContext(v context.Context) LimitsOption {
+	return func(r *LimitsRequest) error {
+		r.ctx = v
+		return nil
+	}
+}
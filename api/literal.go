@@ -0,0 +1,167 @@
+package api
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/0xArch3r/goforce/types"
+)
+
+// Literal is a SOQL fragment inserted into queries verbatim, such as a date literal. Never build a Literal from
+// user input.
+type Literal string
+
+// Date literals relative to the current day.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.soql_sosl.meta/soql_sosl/sforce_api_calls_soql_select_dateformats.htm
+const (
+	Yesterday Literal = "YESTERDAY"
+	Today     Literal = "TODAY"
+	Tomorrow  Literal = "TOMORROW"
+	LastWeek  Literal = "LAST_WEEK"
+	ThisWeek  Literal = "THIS_WEEK"
+	NextWeek  Literal = "NEXT_WEEK"
+	LastMonth Literal = "LAST_MONTH"
+	ThisMonth Literal = "THIS_MONTH"
+	NextMonth Literal = "NEXT_MONTH"
+	LastYear  Literal = "LAST_YEAR"
+	ThisYear  Literal = "THIS_YEAR"
+	NextYear  Literal = "NEXT_YEAR"
+)
+
+// LastNDays is the date literal LAST_N_DAYS:n.
+func LastNDays(n int) Literal {
+	return Literal(fmt.Sprintf("LAST_N_DAYS:%d", n))
+}
+
+// NextNDays is the date literal NEXT_N_DAYS:n.
+func NextNDays(n int) Literal {
+	return Literal(fmt.Sprintf("NEXT_N_DAYS:%d", n))
+}
+
+// NDaysAgo is the date literal N_DAYS_AGO:n.
+func NDaysAgo(n int) Literal {
+	return Literal(fmt.Sprintf("N_DAYS_AGO:%d", n))
+}
+
+// soqlEscaper escapes the characters SOQL requires to be escaped in quoted strings.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.soql_sosl.meta/soql_sosl/sforce_api_calls_soql_select_quotedstringescapes.htm
+var soqlEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	`'`, `\'`,
+	`"`, `\"`,
+	"\n", `\n`,
+	"\r", `\r`,
+	"\t", `\t`,
+	"\b", `\b`,
+	"\f", `\f`,
+)
+
+// QuoteString returns s as quoted SOQL string literal.
+func QuoteString(s string) string {
+	return "'" + soqlEscaper.Replace(s) + "'"
+}
+
+// likeEscaper escapes the wildcards of LIKE patterns and the backslash introducing the escapes.
+var likeEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	`%`, `\%`,
+	`_`, `\_`,
+)
+
+// EscapeLike escapes the wildcards % and _ in s, so that s only matches itself when used in a Like pattern, e.g.
+// Like("Name", EscapeLike(prefix)+"%").
+func EscapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
+
+// quoteLike returns pattern as quoted SOQL string literal. Unlike QuoteString it keeps the escapes \%, \_ and \\
+// of LIKE patterns, while a backslash before any other character stands for itself.
+func quoteLike(pattern string) string {
+	var out strings.Builder
+	out.WriteByte('\'')
+	start := 0
+	for i := 0; i < len(pattern); i++ {
+		if pattern[i] == '\\' && i+1 < len(pattern) && strings.IndexByte(`%_\`, pattern[i+1]) >= 0 {
+			out.WriteString(soqlEscaper.Replace(pattern[start:i]))
+			out.WriteString(pattern[i : i+2])
+			i++
+			start = i + 1
+		}
+	}
+	out.WriteString(soqlEscaper.Replace(pattern[start:]))
+	out.WriteByte('\'')
+	return out.String()
+}
+
+// FormatLiteral formats a Go value as SOQL literal: strings are quoted and escaped, time.Time becomes a UTC datetime,
+// types.Date a date, nil a null, and slices a parenthesized list for IN. Literal values are inserted verbatim. NaN,
+// infinities and []byte have no SOQL form and are rejected.
+func FormatLiteral(v interface{}) (string, error) {
+	switch v := v.(type) {
+	case nil:
+		return "null", nil
+	case Literal:
+		return string(v), nil
+	case string:
+		return QuoteString(v), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case time.Time:
+		return v.UTC().Format("2006-01-02T15:04:05Z"), nil
+	case types.Date:
+		return v.String(), nil
+	case *types.Date:
+		if v == nil {
+			return "null", nil
+		}
+		return v.String(), nil
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Pointer:
+		if rv.IsNil() {
+			return "null", nil
+		}
+		return FormatLiteral(rv.Elem().Interface())
+	case reflect.String:
+		return QuoteString(rv.String()), nil
+	case reflect.Bool:
+		return strconv.FormatBool(rv.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return "", fmt.Errorf("cannot format %v as SOQL literal", f)
+		}
+		if rv.Kind() == reflect.Float32 {
+			return strconv.FormatFloat(f, 'f', -1, 32), nil
+		}
+		return strconv.FormatFloat(f, 'f', -1, 64), nil
+	case reflect.Slice, reflect.Array:
+		// Bytes have no SOQL representation, and a list of numbers is rarely what was meant.
+		if rv.Type().Elem() == reflect.TypeOf(byte(0)) {
+			return "", fmt.Errorf("cannot format %T as SOQL literal, convert it to string first", v)
+		}
+		if rv.Len() == 0 {
+			return "", fmt.Errorf("cannot format empty %T as list", v)
+		}
+		items := make([]string, rv.Len())
+		for i := range items {
+			item, err := FormatLiteral(rv.Index(i).Interface())
+			if err != nil {
+				return "", err
+			}
+			items[i] = item
+		}
+		return "(" + strings.Join(items, ", ") + ")", nil
+	}
+	return "", fmt.Errorf("cannot format %T as SOQL literal", v)
+}
@@ -0,0 +1,23 @@
+package api
+
+import "net/http"
+
+// TransportFunc adapts an ordinary function to the Transport interface.
+type TransportFunc func(*http.Request) (*Response, error)
+
+// Perform calls f(req).
+func (f TransportFunc) Perform(req *http.Request) (*Response, error) {
+	return f(req)
+}
+
+// Middleware wraps a Transport to observe or alter requests and responses, e.g. for logging, metrics or header
+// injection.
+type Middleware func(next Transport) Transport
+
+// Chain wraps transport with middlewares. The first middleware is the outermost one and sees every request first.
+func Chain(transport Transport, middlewares ...Middleware) Transport {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		transport = middlewares[i](transport)
+	}
+	return transport
+}
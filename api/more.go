@@ -0,0 +1,78 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/0xArch3r/goforce/types"
+)
+
+// More fetches the next batch of a query result from its NextRecordsURL.
+type More func(nextURL string, opts ...MoreOption) (*types.QueryResult, error)
+
+func newMoreFunc(base Transport) More {
+	return func(nextURL string, opts ...MoreOption) (*types.QueryResult, error) {
+		if nextURL == "" {
+			return nil, errors.New("next records url cannot be empty")
+		}
+		r := MoreRequest{
+			URL: nextURL,
+		}
+		for _, f := range opts {
+			err := f(&r)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := r.Do(r.ctx, base)
+		if err != nil {
+			return nil, err
+		}
+
+		res := &types.QueryResult{}
+		err = resp.decode(res)
+		if err != nil {
+			return nil, err
+		}
+		return res, nil
+	}
+}
+
+type MoreOption func(*MoreRequest) error
+
+type MoreRequest struct {
+	ctx context.Context
+	URL string
+}
+
+func (r MoreRequest) Do(ctx context.Context, transport Transport) (*Response, error) {
+	method := http.MethodGet
+
+	// The url returned by salesforce is already rooted at /services/data/vXX.X.
+	req, err := http.NewRequest(method, r.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	} else {
+		req = req.WithContext(context.Background())
+	}
+
+	res, err := transport.Perform(req)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// WithContext sets the request context.
+func (f More) WithContext(v context.Context) MoreOption {
+	return func(r *MoreRequest) error {
+		r.ctx = v
+		return nil
+	}
+}
@@ -0,0 +1,89 @@
+package api
+
+import (
+	"context"
+	"iter"
+	"sync"
+
+	"github.com/0xArch3r/goforce/types"
+)
+
+// parallel runs every query through run with up to workers at a time and yields the records as they arrive.
+// Iteration ends at the first error. All workers have finished by the time it returns, and they are cancelled
+// through the context handed to run as soon as the caller stops iterating.
+func parallel(parent context.Context, queries []string, workers int, run func(ctx context.Context, query string) iter.Seq2[types.SObject, error], yield func(types.SObject, error) bool) {
+	if parent == nil {
+		parent = context.Background()
+	}
+	ctx, cancel := context.WithCancel(parent)
+	defer cancel()
+
+	if workers < 1 || workers > len(queries) {
+		workers = len(queries)
+	}
+
+	type item struct {
+		record types.SObject
+		err    error
+	}
+	items := make(chan item)
+	jobs := make(chan string)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for query := range jobs {
+				for record, err := range run(ctx, query) {
+					select {
+					case items <- item{record, err}:
+					case <-ctx.Done():
+						return
+					}
+					if err != nil {
+						return
+					}
+				}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(jobs)
+		for _, query := range queries {
+			select {
+			case jobs <- query:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	go func() {
+		wg.Wait()
+		close(items)
+	}()
+
+	// Stop the workers and wait for them before returning, whichever way iteration ends.
+	defer func() {
+		cancel()
+		for range items {
+		}
+	}()
+
+	for it := range items {
+		if it.err != nil {
+			yield(nil, it.err)
+			return
+		}
+		if !yield(it.record, nil) {
+			return
+		}
+	}
+
+	// Workers only give up silently when the caller's context is done.
+	if err := parent.Err(); err != nil {
+		yield(nil, err)
+	}
+}
@@ -2,12 +2,11 @@ package api
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 	"net/http"
 	"net/url"
+	"reflect"
 	"strings"
 
 	"github.com/0xArch3r/goforce/types"
@@ -17,6 +16,8 @@ import (
 type Select func(object string, opts ...SelectOption) (*types.QueryResult, error)
 
 func newSelectFunc(base Transport) Select {
+	more := newMoreFunc(base)
+
 	return func(object string, opts ...SelectOption) (*types.QueryResult, error) {
 		r := SelectRequest{
 			Object: object,
@@ -34,15 +35,29 @@ func newSelectFunc(base Transport) Select {
 			return nil, err
 		}
 
-		data, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
 		res := &types.QueryResult{}
-		err = json.Unmarshal(data, res)
+		err = resp.decode(res)
 		if err != nil {
 			return nil, err
 		}
+
+		if r.into != nil {
+			// Collect the remaining batches so that the target holds the complete result.
+			for !res.Done && res.NextRecordsURL != "" {
+				next, err := more(res.NextRecordsURL, more.WithContext(r.ctx))
+				if err != nil {
+					return nil, err
+				}
+				res.Records = append(res.Records, next.Records...)
+				res.Done = next.Done
+				res.NextRecordsURL = next.NextRecordsURL
+			}
+
+			err = res.Decode(r.into)
+			if err != nil {
+				return nil, err
+			}
+		}
 		return res, nil
 	}
 }
@@ -50,20 +65,50 @@ func newSelectFunc(base Transport) Select {
 type SelectOption func(*SelectRequest) error
 
 type SelectRequest struct {
-	ctx     context.Context
-	Object  string
-	Fields  []string
-	Limit   int
-	OrderBy string
-}
+	ctx       context.Context
+	Object    string
+	Fields    []string
+	Where     Condition
+	GroupBy   []string
+	Having    Condition
+	OrderBy   string
+	Limit     int
+	Offset    int
+	For       string
+	QueryAll  bool
+	Tooling   bool
+	BatchSize int
+	Prefetch  int
 
-func (r SelectRequest) Do(ctx context.Context, transport Transport) (*Response, error) {
-	method := http.MethodGet
+	into interface{}
+}
 
+// SOQL builds the query described by the request.
+func (r SelectRequest) SOQL() (string, error) {
 	fields := strings.Join(r.Fields, ",")
 
 	query := fmt.Sprintf("SELECT %v FROM %v", fields, r.Object)
 
+	if r.Where != nil {
+		where, err := r.Where.SOQL()
+		if err != nil {
+			return "", err
+		}
+		query = fmt.Sprintf("%v WHERE %v", query, where)
+	}
+
+	if len(r.GroupBy) > 0 {
+		query = fmt.Sprintf("%v GROUP BY %v", query, strings.Join(r.GroupBy, ","))
+	}
+
+	if r.Having != nil {
+		having, err := r.Having.SOQL()
+		if err != nil {
+			return "", err
+		}
+		query = fmt.Sprintf("%v HAVING %v", query, having)
+	}
+
 	if r.OrderBy != "" {
 		query = fmt.Sprintf("%v ORDER BY %v", query, r.OrderBy)
 	}
@@ -72,13 +117,44 @@ func (r SelectRequest) Do(ctx context.Context, transport Transport) (*Response,
 		query = fmt.Sprintf("%v LIMIT %v", query, r.Limit)
 	}
 
-	path := fmt.Sprintf("/query?q=%s", url.QueryEscape(query))
+	if r.Offset > 0 {
+		query = fmt.Sprintf("%v OFFSET %v", query, r.Offset)
+	}
+
+	if r.For != "" {
+		query = fmt.Sprintf("%v FOR %v", query, r.For)
+	}
+
+	return query, nil
+}
+
+func (r SelectRequest) Do(ctx context.Context, transport Transport) (*Response, error) {
+	method := http.MethodGet
+
+	query, err := r.SOQL()
+	if err != nil {
+		return nil, err
+	}
+
+	resource := "query"
+	if r.QueryAll {
+		resource = "queryAll"
+	}
+
+	path := fmt.Sprintf("/%s?q=%s", resource, url.QueryEscape(query))
+	if r.Tooling {
+		path = "/tooling" + path
+	}
 
 	req, err := http.NewRequest(method, path, nil)
 	if err != nil {
 		return nil, err
 	}
 
+	if r.BatchSize > 0 {
+		req.Header.Set("Sforce-Query-Options", fmt.Sprintf("batchSize=%d", r.BatchSize))
+	}
+
 	if ctx != nil {
 		req = req.WithContext(ctx)
 	} else {
@@ -123,3 +199,130 @@ func (f Select) OrderBy(orderBy string) SelectOption {
 		return nil
 	}
 }
+
+// Where filters the records by condition. Calling it more than once requires all conditions to match.
+func (f Select) Where(condition Condition) SelectOption {
+	return func(r *SelectRequest) error {
+		if condition == nil {
+			return errors.New("condition cannot be nil")
+		}
+		if r.Where != nil {
+			condition = And(r.Where, condition)
+		}
+		r.Where = condition
+		return nil
+	}
+}
+
+func (f Select) GroupBy(fields ...string) SelectOption {
+	return func(r *SelectRequest) error {
+		r.GroupBy = fields
+		return nil
+	}
+}
+
+// Having filters the groups of a GroupBy query by condition, e.g. api.Gt("COUNT(Id)", 1).
+func (f Select) Having(condition Condition) SelectOption {
+	return func(r *SelectRequest) error {
+		if condition == nil {
+			return errors.New("condition cannot be nil")
+		}
+		r.Having = condition
+		return nil
+	}
+}
+
+func (f Select) Offset(offset int) SelectOption {
+	return func(r *SelectRequest) error {
+		if offset < 0 {
+			return errors.New("offset cannot be negative")
+		} else if offset > 2000 {
+			return errors.New("offset exceeds maximum")
+		}
+		r.Offset = offset
+		return nil
+	}
+}
+
+// ForView updates the LastViewedDate of the returned records.
+func (f Select) ForView() SelectOption {
+	return func(r *SelectRequest) error {
+		r.For = "VIEW"
+		return nil
+	}
+}
+
+// ForReference updates the LastReferencedDate of the returned records.
+func (f Select) ForReference() SelectOption {
+	return func(r *SelectRequest) error {
+		r.For = "REFERENCE"
+		return nil
+	}
+}
+
+// ForUpdate locks the returned records against concurrent updates.
+func (f Select) ForUpdate() SelectOption {
+	return func(r *SelectRequest) error {
+		r.For = "UPDATE"
+		return nil
+	}
+}
+
+// Tooling sends the request to the Tooling API.
+func (f Select) Tooling() SelectOption {
+	return func(r *SelectRequest) error {
+		r.Tooling = true
+		return nil
+	}
+}
+
+// Into selects the fields described by the sf tags of the struct type v points to and decodes the records into v,
+// which must point to a slice, e.g. &[]Contact{}. Every batch of the result is fetched. See types.FieldsOf for how
+// the field list is derived.
+func (f Select) Into(v interface{}) SelectOption {
+	return func(r *SelectRequest) error {
+		fields, err := types.FieldsOf(v)
+		if err != nil {
+			return err
+		}
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Slice {
+			return errors.New("into target must be a non-nil pointer to a slice")
+		}
+		r.Fields = fields
+		r.into = v
+		return nil
+	}
+}
+
+// QueryAll includes deleted and archived records in the result, e.g. to find records with IsDeleted = true.
+func (f Select) QueryAll() SelectOption {
+	return func(r *SelectRequest) error {
+		r.QueryAll = true
+		return nil
+	}
+}
+
+// BatchSize requests batches of size records instead of the default 2000. Salesforce treats it as a hint and may
+// return smaller batches.
+func (f Select) BatchSize(size int) SelectOption {
+	return func(r *SelectRequest) error {
+		if size < 200 || size > 2000 {
+			return errors.New("batch size must be between 200 and 2000")
+		}
+		r.BatchSize = size
+		return nil
+	}
+}
+
+// Prefetch makes SelectIter fetch up to depth batches ahead in the background while the caller processes the current
+// one. Prefetched batches are decoded completely, so memory grows with depth. It has no effect on Select itself.
+func (f Select) Prefetch(depth int) SelectOption {
+	return func(r *SelectRequest) error {
+		if depth < 0 {
+			return errors.New("prefetch depth cannot be negative")
+		}
+		r.Prefetch = depth
+		return nil
+	}
+}
@@ -2,9 +2,8 @@ package api
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"net/http"
 	"net/url"
 
@@ -31,12 +30,8 @@ func newRawQueryFunc(base Transport) RawQuery {
 			return nil, err
 		}
 
-		data, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
 		res := &types.QueryResult{}
-		err = json.Unmarshal(data, res)
+		err = resp.decode(res)
 		if err != nil {
 			return nil, err
 		}
@@ -47,20 +42,51 @@ func newRawQueryFunc(base Transport) RawQuery {
 type RawQueryOption func(*RawQueryRequest) error
 
 type RawQueryRequest struct {
-	ctx   context.Context
-	Query string
+	ctx       context.Context
+	Query     string
+	Named     map[string]interface{}
+	Args      []interface{}
+	QueryAll  bool
+	Tooling   bool
+	BatchSize int
+	Prefetch  int
+}
+
+// SOQL returns the query with the bound values filled in.
+func (r RawQueryRequest) SOQL() (string, error) {
+	if r.Named == nil && r.Args == nil {
+		return r.Query, nil
+	}
+	return bind(r.Query, r.Named, r.Args)
 }
 
 func (r RawQueryRequest) Do(ctx context.Context, transport Transport) (*Response, error) {
 	method := http.MethodGet
 
-	path := fmt.Sprintf("/query?q=%s", url.QueryEscape(r.Query))
+	query, err := r.SOQL()
+	if err != nil {
+		return nil, err
+	}
+
+	resource := "query"
+	if r.QueryAll {
+		resource = "queryAll"
+	}
+
+	path := fmt.Sprintf("/%s?q=%s", resource, url.QueryEscape(query))
+	if r.Tooling {
+		path = "/tooling" + path
+	}
 
 	req, err := http.NewRequest(method, path, nil)
 	if err != nil {
 		return nil, err
 	}
 
+	if r.BatchSize > 0 {
+		req.Header.Set("Sforce-Query-Options", fmt.Sprintf("batchSize=%d", r.BatchSize))
+	}
+
 	if ctx != nil {
 		req = req.WithContext(ctx)
 	} else {
@@ -81,3 +107,43 @@ func (f RawQuery) WithContext(v context.Context) RawQueryOption {
 		return nil
 	}
 }
+
+// Tooling sends the query to the Tooling API, e.g. to query ApexClass or MetadataComponentDependency.
+func (f RawQuery) Tooling() RawQueryOption {
+	return func(r *RawQueryRequest) error {
+		r.Tooling = true
+		return nil
+	}
+}
+
+// QueryAll includes deleted and archived records in the result, e.g. to find records with IsDeleted = true.
+func (f RawQuery) QueryAll() RawQueryOption {
+	return func(r *RawQueryRequest) error {
+		r.QueryAll = true
+		return nil
+	}
+}
+
+// BatchSize requests batches of size records instead of the default 2000. Salesforce treats it as a hint and may
+// return smaller batches.
+func (f RawQuery) BatchSize(size int) RawQueryOption {
+	return func(r *RawQueryRequest) error {
+		if size < 200 || size > 2000 {
+			return errors.New("batch size must be between 200 and 2000")
+		}
+		r.BatchSize = size
+		return nil
+	}
+}
+
+// Prefetch makes Iter fetch up to depth batches ahead in the background while the caller processes the current
+// one. Prefetched batches are decoded completely, so memory grows with depth. It has no effect on RawQuery itself.
+func (f RawQuery) Prefetch(depth int) RawQueryOption {
+	return func(r *RawQueryRequest) error {
+		if depth < 0 {
+			return errors.New("prefetch depth cannot be negative")
+		}
+		r.Prefetch = depth
+		return nil
+	}
+}
@@ -2,12 +2,15 @@ package api
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"strconv"
 	"strings"
+
+	"github.com/0xArch3r/goforce/types"
 )
 
 // Response represents the API response.
@@ -80,3 +83,23 @@ func (r *Response) Warnings() []string {
 func (r *Response) HasWarnings() bool {
 	return len(r.Warnings()) > 0
 }
+
+// decode reads and closes the response body. Failed requests are returned as types.SalesforceError, otherwise the
+// body is unmarshalled into v. A nil v or an empty body leaves v untouched.
+func (r *Response) decode(v interface{}) error {
+	defer r.Body.Close()
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return err
+	}
+
+	if r.IsError() {
+		return types.ParseSalesforceError(r.StatusCode, data)
+	}
+
+	if v == nil || len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, v)
+}
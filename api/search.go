@@ -5,7 +5,6 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
-	"io"
 	"net/http"
 	"strings"
 
@@ -32,13 +31,8 @@ func newSearchFunc(b Transport) Search {
 			return nil, err
 		}
 
-		data, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
-
 		res := &types.SearchResults{}
-		err = json.Unmarshal(data, res)
+		err = resp.decode(res)
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,137 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"iter"
+
+	"github.com/0xArch3r/goforce/types"
+)
+
+// stream decodes the JSON object of the response body token by token and calls yield for every element of the
+// array stored under key, so that only a single record is held in memory at a time. The other fields of the object
+// are unmarshalled into meta. Streaming stops early when yield returns false. The body is always closed.
+func (r *Response) stream(key string, meta interface{}, yield func(types.SObject) bool) error {
+	if r.IsError() {
+		return r.decode(nil)
+	}
+	defer r.Body.Close()
+
+	dec := json.NewDecoder(r.Body)
+	err := expectDelim(dec, '{')
+	if err != nil {
+		return err
+	}
+
+	fields := map[string]json.RawMessage{}
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		name, ok := token.(string)
+		if !ok {
+			return fmt.Errorf("unexpected %v in response, expected a field name", token)
+		}
+
+		if name != key {
+			var value json.RawMessage
+			err = dec.Decode(&value)
+			if err != nil {
+				return err
+			}
+			fields[name] = value
+			continue
+		}
+
+		token, err = dec.Token()
+		if err != nil {
+			return err
+		}
+		// Salesforce sends null instead of an empty array at times.
+		if token == nil {
+			continue
+		}
+		if delim, ok := token.(json.Delim); !ok || delim != '[' {
+			return fmt.Errorf("unexpected %v in response, expected an array of records", token)
+		}
+		for dec.More() {
+			record := types.SObject{}
+			err = dec.Decode(&record)
+			if err != nil {
+				return err
+			}
+			if !yield(record) {
+				return nil
+			}
+		}
+		err = expectDelim(dec, ']')
+		if err != nil {
+			return err
+		}
+	}
+
+	err = expectDelim(dec, '}')
+	if err != nil {
+		return err
+	}
+
+	if meta == nil {
+		return nil
+	}
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, meta)
+}
+
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if d, ok := token.(json.Delim); !ok || d != delim {
+		return fmt.Errorf("unexpected %v in response, expected %v", token, delim)
+	}
+	return nil
+}
+
+// SearchIter runs a parameterized search like Search but decodes the records one at a time while they are read
+// from the response. It accepts the same options as Search.
+type SearchIter func(query string, o ...SearchOption) iter.Seq2[types.SObject, error]
+
+func newSearchIterFunc(b Transport) SearchIter {
+	return func(query string, o ...SearchOption) iter.Seq2[types.SObject, error] {
+		return func(yield func(types.SObject, error) bool) {
+			r := SearchRequest{
+				Query:        query,
+				In:           "ALL",
+				DefaultLimit: 2000,
+				OverallLimit: 2000,
+			}
+			for _, f := range o {
+				err := f(&r)
+				if err != nil {
+					yield(nil, err)
+					return
+				}
+			}
+
+			resp, err := r.Do(r.ctx, b)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			stopped := false
+			err = resp.stream("searchRecords", nil, func(record types.SObject) bool {
+				stopped = !yield(record, nil)
+				return !stopped
+			})
+			if err != nil && !stopped {
+				yield(nil, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/0xArch3r/goforce/types"
+)
+
+func newUpdateFunc(b Transport) Update {
+	return func(object string, id string, fields types.SObject, o ...UpdateOption) error {
+		r := UpdateRequest{Object: object, ID: id, Fields: fields}
+		for _, f := range o {
+			err := f(&r)
+			if err != nil {
+				return err
+			}
+		}
+
+		resp, err := r.Do(r.ctx, b)
+		if err != nil {
+			return err
+		}
+		return resp.decode(nil)
+	}
+}
+
+// Update modifies the given fields of an existing record.
+type Update func(object string, id string, fields types.SObject, o ...UpdateOption) error
+
+type UpdateOption func(*UpdateRequest) error
+
+// UpdateRequest configures the Update API request.
+type UpdateRequest struct {
+	Object  string
+	ID      string
+	Fields  types.SObject
+	Tooling bool
+
+	ctx context.Context
+}
+
+// Do executes the request and returns response or error.
+func (r UpdateRequest) Do(ctx context.Context, transport Transport) (*Response, error) {
+	method := http.MethodPatch
+	path := fmt.Sprintf("/sobjects/%v/%v", r.Object, r.ID)
+	if r.Tooling {
+		path = "/tooling" + path
+	}
+
+	// The Id is already part of the path and salesforce rejects it in the body.
+	fields := r.Fields.Payload()
+	delete(fields, "Id")
+
+	payload, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(method, path, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	} else {
+		req = req.WithContext(context.Background())
+	}
+
+	res, err := transport.Perform(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// WithContext sets the request context.
+func (f Update) WithContext(v context.Context) UpdateOption {
+	return func(r *UpdateRequest) error {
+		r.ctx = v
+		return nil
+	}
+}
+
+// Tooling sends the request to the Tooling API.
+func (f Update) Tooling() UpdateOption {
+	return func(r *UpdateRequest) error {
+		r.Tooling = true
+		return nil
+	}
+}
@@ -0,0 +1,138 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/0xArch3r/goforce/types"
+)
+
+func newUpsertFunc(b Transport) Upsert {
+	return func(object string, fields types.SObject, o ...UpsertOption) (*types.UpsertResult, error) {
+		if object == "" {
+			object = fields.Type()
+		}
+		r := UpsertRequest{
+			Object:        object,
+			ExternalField: fields.ExternalIDFieldName(),
+			ExternalID:    fields.ExternalID(),
+			Fields:        fields,
+		}
+		for _, f := range o {
+			err := f(&r)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if r.Object == "" {
+			return nil, errors.New("object type is required")
+		}
+		if r.ExternalField == "" || r.ExternalID == "" {
+			return nil, errors.New("external id field and value are required")
+		}
+
+		resp, err := r.Do(r.ctx, b)
+		if err != nil {
+			return nil, err
+		}
+
+		res := &types.UpsertResult{}
+		err = resp.decode(res)
+		if err != nil {
+			return nil, err
+		}
+		// Older API versions answer an update with 204 and no body.
+		if resp.StatusCode == http.StatusNoContent {
+			res.Success = true
+		}
+		res.Created = resp.StatusCode == http.StatusCreated
+		return res, nil
+	}
+}
+
+// Upsert inserts or updates a record matched by an external ID. By default the external ID field and value are
+// taken from fields.ExternalIDFieldName() and fields.ExternalID(); use ExternalID to set them explicitly.
+type Upsert func(object string, fields types.SObject, o ...UpsertOption) (*types.UpsertResult, error)
+
+type UpsertOption func(*UpsertRequest) error
+
+// UpsertRequest configures the Upsert API request.
+type UpsertRequest struct {
+	Object        string
+	ExternalField string
+	ExternalID    string
+	Fields        types.SObject
+	Tooling       bool
+
+	ctx context.Context
+}
+
+// Do executes the request and returns response or error.
+func (r UpsertRequest) Do(ctx context.Context, transport Transport) (*Response, error) {
+	method := http.MethodPatch
+	path := fmt.Sprintf("/sobjects/%v/%v/%v", r.Object, r.ExternalField, url.PathEscape(r.ExternalID))
+	if r.Tooling {
+		path = "/tooling" + path
+	}
+
+	// The record is identified by the path, so neither the Id nor the external ID belong in the body.
+	fields := r.Fields.Payload()
+	delete(fields, "Id")
+	delete(fields, r.ExternalField)
+
+	payload, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(method, path, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	} else {
+		req = req.WithContext(context.Background())
+	}
+
+	res, err := transport.Perform(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// WithContext sets the request context.
+func (f Upsert) WithContext(v context.Context) UpsertOption {
+	return func(r *UpsertRequest) error {
+		r.ctx = v
+		return nil
+	}
+}
+
+// ExternalID sets the external ID field and the value used to match the record.
+func (f Upsert) ExternalID(field string, value string) UpsertOption {
+	return func(r *UpsertRequest) error {
+		if field == "" || value == "" {
+			return errors.New("external id field and value cannot be empty")
+		}
+		r.ExternalField = field
+		r.ExternalID = value
+		return nil
+	}
+}
+
+// Tooling sends the request to the Tooling API.
+func (f Upsert) Tooling() UpsertOption {
+	return func(r *UpsertRequest) error {
+		r.Tooling = true
+		return nil
+	}
+}
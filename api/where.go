@@ -0,0 +1,179 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// Condition is a filter of a WHERE or HAVING clause. Values are escaped by the builders, field names must be trusted.
+type Condition interface {
+	SOQL() (string, error)
+}
+
+type conditionFunc func() (string, error)
+
+func (f conditionFunc) SOQL() (string, error) {
+	return f()
+}
+
+// fieldPattern matches field names, relationship paths and simple function calls such as COUNT(Id).
+var fieldPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.]*(\([A-Za-z0-9_.]*\))?$`)
+
+func checkField(field string) error {
+	if !fieldPattern.MatchString(field) {
+		return fmt.Errorf("invalid field name %q", field)
+	}
+	return nil
+}
+
+// compare builds field op value.
+func compare(field, op string, value interface{}) Condition {
+	return conditionFunc(func() (string, error) {
+		err := checkField(field)
+		if err != nil {
+			return "", err
+		}
+		literal, err := FormatLiteral(value)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%v %v %v", field, op, literal), nil
+	})
+}
+
+// Eq matches records whose field equals value.
+func Eq(field string, value interface{}) Condition {
+	return compare(field, "=", value)
+}
+
+// Ne matches records whose field differs from value.
+func Ne(field string, value interface{}) Condition {
+	return compare(field, "!=", value)
+}
+
+// Lt matches records whose field is less than value.
+func Lt(field string, value interface{}) Condition {
+	return compare(field, "<", value)
+}
+
+// Le matches records whose field is less than or equal to value.
+func Le(field string, value interface{}) Condition {
+	return compare(field, "<=", value)
+}
+
+// Gt matches records whose field is greater than value.
+func Gt(field string, value interface{}) Condition {
+	return compare(field, ">", value)
+}
+
+// Ge matches records whose field is greater than or equal to value.
+func Ge(field string, value interface{}) Condition {
+	return compare(field, ">=", value)
+}
+
+// Like matches records whose field matches pattern, using % and _ as wildcards. \% and \_ match the characters
+// themselves; use EscapeLike to escape user input.
+func Like(field string, pattern string) Condition {
+	return compare(field, "LIKE", Literal(quoteLike(pattern)))
+}
+
+// IsNull matches records whose field is empty.
+func IsNull(field string) Condition {
+	return compare(field, "=", nil)
+}
+
+// NotNull matches records whose field is set.
+func NotNull(field string) Condition {
+	return compare(field, "!=", nil)
+}
+
+// list checks that values is a non-empty slice before it is formatted.
+func list(field, op string, values interface{}) Condition {
+	return conditionFunc(func() (string, error) {
+		rv := reflect.ValueOf(values)
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			return "", fmt.Errorf("%v requires a slice of values, got %T", op, values)
+		}
+		if rv.Len() == 0 {
+			return "", fmt.Errorf("%v requires at least one value", op)
+		}
+		return compare(field, op, values).SOQL()
+	})
+}
+
+// In matches records whose field equals one of values, which must be a slice, e.g. []string.
+func In(field string, values interface{}) Condition {
+	return list(field, "IN", values)
+}
+
+// NotIn matches records whose field equals none of values, which must be a slice.
+func NotIn(field string, values interface{}) Condition {
+	return list(field, "NOT IN", values)
+}
+
+// Includes matches records whose multi-select picklist field contains any of values. A value may combine several
+// items with a semicolon to require all of them, e.g. "AAA;BBB".
+func Includes(field string, values ...string) Condition {
+	return list(field, "INCLUDES", values)
+}
+
+// Excludes matches records whose multi-select picklist field contains none of values.
+func Excludes(field string, values ...string) Condition {
+	return list(field, "EXCLUDES", values)
+}
+
+// join combines conditions with op, parenthesizing each of them.
+func join(op string, conditions []Condition) Condition {
+	return conditionFunc(func() (string, error) {
+		if len(conditions) == 0 {
+			return "", fmt.Errorf("%v requires at least one condition", op)
+		}
+
+		parts := make([]string, len(conditions))
+		for i, c := range conditions {
+			if c == nil {
+				return "", errors.New("condition cannot be nil")
+			}
+			part, err := c.SOQL()
+			if err != nil {
+				return "", err
+			}
+			parts[i] = part
+		}
+		if len(parts) == 1 {
+			return parts[0], nil
+		}
+
+		for i, part := range parts {
+			parts[i] = "(" + part + ")"
+		}
+		return strings.Join(parts, " "+op+" "), nil
+	})
+}
+
+// And matches records satisfying all conditions.
+func And(conditions ...Condition) Condition {
+	return join("AND", conditions)
+}
+
+// Or matches records satisfying any of conditions.
+func Or(conditions ...Condition) Condition {
+	return join("OR", conditions)
+}
+
+// Not matches records not satisfying condition.
+func Not(condition Condition) Condition {
+	return conditionFunc(func() (string, error) {
+		if condition == nil {
+			return "", errors.New("condition cannot be nil")
+		}
+		part, err := condition.SOQL()
+		if err != nil {
+			return "", err
+		}
+		return "NOT (" + part + ")", nil
+	})
+}
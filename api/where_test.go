@@ -0,0 +1,77 @@
+package api
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLike(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    string
+	}{
+		{"Acme%", `Name LIKE 'Acme%'`},
+		{`50\%`, `Name LIKE '50\%'`},
+		{`a\_b`, `Name LIKE 'a\_b'`},
+		{`C:\\Temp`, `Name LIKE 'C:\\Temp'`},
+		{`C:\Temp`, `Name LIKE 'C:\\Temp'`},
+		{`trailing\`, `Name LIKE 'trailing\\'`},
+		{"O'Brien%", `Name LIKE 'O\'Brien%'`},
+		{"Müller_%", `Name LIKE 'Müller_%'`},
+		{EscapeLike(`50%_off\`) + "%", `Name LIKE '50\%\_off\\%'`},
+	}
+
+	for _, tt := range tests {
+		got, err := Like("Name", tt.pattern).SOQL()
+		if err != nil {
+			t.Fatalf("Like(%q): %v", tt.pattern, err)
+		}
+		if got != tt.want {
+			t.Errorf("Like(%q) = %v, want %v", tt.pattern, got, tt.want)
+		}
+	}
+}
+
+func TestFormatLiteralFloat(t *testing.T) {
+	tests := []struct {
+		value interface{}
+		want  string
+	}{
+		{float32(0.1), "0.1"},
+		{float64(0.1), "0.1"},
+		{float32(12.5), "12.5"},
+		{1e21, "1000000000000000000000"},
+	}
+
+	for _, tt := range tests {
+		got, err := FormatLiteral(tt.value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("FormatLiteral(%#v) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestFormatLiteralRejects(t *testing.T) {
+	for _, value := range []interface{}{
+		math.NaN(),
+		math.Inf(1),
+		float32(math.Inf(-1)),
+		[]float64{1, math.NaN()},
+		[]byte("abc"),
+		[3]byte{1, 2, 3},
+		[]string{},
+	} {
+		if got, err := FormatLiteral(value); err == nil {
+			t.Errorf("FormatLiteral(%#v) = %v, want an error", value, got)
+		}
+	}
+
+	type level uint8
+	got, err := FormatLiteral([]level{1, 2})
+	if err != nil || got != "(1, 2)" {
+		t.Errorf("FormatLiteral([]level) = %v, %v, want (1, 2)", got, err)
+	}
+}
@@ -0,0 +1,72 @@
+package goforce
+
+import "strings"
+
+// credentials returns the current session ID and instance URL, asking the TokenSource when one is configured.
+func (c *BaseClient) credentials() (string, string, error) {
+	c.mu.RLock()
+	session, instance, ts := c.SessionID, c.InstanceURL, c.TokenSource
+	c.mu.RUnlock()
+
+	if ts == nil {
+		return session, instance, nil
+	}
+
+	tok, err := ts.Token()
+	if err != nil {
+		return "", "", err
+	}
+	if tok.InstanceURL != "" {
+		instance = strings.TrimSuffix(tok.InstanceURL, "/")
+	}
+	return tok.AccessToken, instance, nil
+}
+
+// canReauthenticate reports whether a rejected session may be renewed: always with a TokenSource, otherwise only
+// when AuthRetry is set.
+func (c *BaseClient) canReauthenticate() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.AuthRetry || c.TokenSource != nil
+}
+
+// reauthenticate renews the session once stale has been rejected, either by refreshing the TokenSource or by
+// signing in again with the credentials of the last login. Concurrent callers wait for the first one; if the session
+// was already renewed in the meantime nothing else happens. It reports whether the rejected request should be
+// replayed.
+func (c *BaseClient) reauthenticate(stale string) (bool, error) {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+
+	c.mu.RLock()
+	session, relogin, ts := c.SessionID, c.relogin, c.TokenSource
+	c.mu.RUnlock()
+
+	if ts != nil {
+		tok, err := ts.Token()
+		if err != nil {
+			return false, err
+		}
+		if tok.AccessToken != stale {
+			return true, nil
+		}
+		_, err = ts.Refresh()
+		if err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+
+	if session != stale {
+		return true, nil
+	}
+	if relogin == nil {
+		return false, nil
+	}
+
+	err := relogin()
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
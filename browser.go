@@ -0,0 +1,203 @@
+package goforce
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/0xArch3r/goforce/types"
+)
+
+const (
+	DefaultCallbackAddr = "localhost:1717"
+	DefaultCallbackPath = "/OauthRedirect"
+)
+
+// BrowserLogin configures LoginBrowser.
+type BrowserLogin struct {
+	ClientID string
+	// ClientSecret is only needed when the connected app requires a secret for the web server flow.
+	ClientSecret string
+	// Addr is the loopback address the callback listener binds to. The callback URL of the connected app must be
+	// http://{Addr}{CallbackPath}. Defaults to DefaultCallbackAddr.
+	Addr string
+	// CallbackPath defaults to DefaultCallbackPath.
+	CallbackPath string
+	// Scopes requested from salesforce; the connected app defaults are used when empty.
+	Scopes []string
+	// Open presents the authorize URL to the user. Defaults to printing it to stderr and opening the system browser.
+	Open func(authURL string) error
+	// OnRefresh receives every renewed token, see LoginToken.
+	OnRefresh func(Token)
+}
+
+// LoginBrowser signs into salesforce interactively with the OAuth 2.0 web server flow and PKCE, which suits command
+// line tools used by SSO users without a password. It listens on a loopback address for the callback, sends the user
+// to the authorize URL and exchanges the returned code for tokens. It blocks until the callback arrives or ctx is done.
+// Ref: https://help.salesforce.com/s/articleView?id=sf.remoteaccess_oauth_web_server_flow.htm
+func (client *BaseClient) LoginBrowser(ctx context.Context, cfg BrowserLogin) error {
+	if cfg.ClientID == "" {
+		return errors.New("client id is required")
+	}
+	if cfg.Addr == "" {
+		cfg.Addr = DefaultCallbackAddr
+	}
+	if cfg.CallbackPath == "" {
+		cfg.CallbackPath = DefaultCallbackPath
+	}
+	if cfg.Open == nil {
+		cfg.Open = printAndOpen
+	}
+
+	verifier, err := randomString()
+	if err != nil {
+		return err
+	}
+	state, err := randomString()
+	if err != nil {
+		return err
+	}
+	challenge := sha256.Sum256([]byte(verifier))
+
+	listener, err := net.Listen("tcp", cfg.Addr)
+	if err != nil {
+		return err
+	}
+	redirectURI := fmt.Sprintf("http://%s%s", cfg.Addr, cfg.CallbackPath)
+
+	params := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {cfg.ClientID},
+		"redirect_uri":          {redirectURI},
+		"state":                 {state},
+		"code_challenge":        {base64.RawURLEncoding.EncodeToString(challenge[:])},
+		"code_challenge_method": {"S256"},
+	}
+	if len(cfg.Scopes) > 0 {
+		params.Set("scope", strings.Join(cfg.Scopes, " "))
+	}
+	authURL := fmt.Sprintf("%s/services/oauth2/authorize?%s", client.BaseURL, params.Encode())
+
+	type callback struct {
+		code string
+		err  error
+	}
+	result := make(chan callback, 1)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(cfg.CallbackPath, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		// Requests without the state of this login, e.g. from a stale tab or another local process, are turned away
+		// while the real callback is still awaited.
+		if subtle.ConstantTimeCompare([]byte(query.Get("state")), []byte(state)) != 1 {
+			http.Error(w, "Unknown login attempt, you can close this window.", http.StatusBadRequest)
+			return
+		}
+
+		var cb callback
+		switch {
+		case query.Get("error") != "":
+			cb.err = types.SalesforceError{
+				Message:      fmt.Sprintf("Error Message:  %v Error Code: %v", query.Get("error_description"), query.Get("error")),
+				ErrorCode:    query.Get("error"),
+				ErrorMessage: query.Get("error_description"),
+			}
+		default:
+			cb.code = query.Get("code")
+		}
+
+		if cb.err != nil {
+			http.Error(w, "Login failed, you can close this window.", http.StatusBadRequest)
+		} else {
+			fmt.Fprint(w, "Login successful, you can close this window.")
+		}
+
+		select {
+		case result <- cb:
+		default:
+		}
+	})
+
+	server := &http.Server{Handler: mux}
+	go server.Serve(listener)
+	defer server.Close()
+
+	err = cfg.Open(authURL)
+	if err != nil {
+		return err
+	}
+
+	var cb callback
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case cb = <-result:
+	}
+	if cb.err != nil {
+		return cb.err
+	}
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {cb.code},
+		"client_id":     {cfg.ClientID},
+		"redirect_uri":  {redirectURI},
+		"code_verifier": {verifier},
+	}
+	if cfg.ClientSecret != "" {
+		form.Set("client_secret", cfg.ClientSecret)
+	}
+
+	tok, err := client.requestToken(form)
+	if err != nil {
+		return err
+	}
+
+	return client.LoginToken(cfg.ClientID, cfg.ClientSecret, Token{
+		AccessToken:  tok.AccessToken,
+		RefreshToken: tok.RefreshToken,
+		InstanceURL:  tok.InstanceURL,
+		ID:           tok.ID,
+	}, cfg.OnRefresh)
+}
+
+// OpenBrowser opens u in the default browser of the system.
+func OpenBrowser(u string) error {
+	switch runtime.GOOS {
+	case "darwin":
+		return exec.Command("open", u).Start()
+	case "windows":
+		return exec.Command("rundll32", "url.dll,FileProtocolHandler", u).Start()
+	default:
+		return exec.Command("xdg-open", u).Start()
+	}
+}
+
+// printAndOpen is the default BrowserLogin.Open. The URL is always printed since opening a browser may fail silently,
+// e.g. over SSH.
+func printAndOpen(authURL string) error {
+	fmt.Fprintf(os.Stderr, "Open the following URL in your browser to sign in:\n\n%s\n\n", authURL)
+	OpenBrowser(authURL)
+	return nil
+}
+
+// randomString returns 32 random bytes encoded for use as PKCE verifier or state.
+func randomString() (string, error) {
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,117 @@
+package goforce
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func freeAddr(t *testing.T) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	return listener.Addr().String()
+}
+
+func TestLoginBrowserIgnoresForeignCallbacks(t *testing.T) {
+	var client *Client
+	client = newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/services/oauth2/token" {
+			http.NotFound(w, r)
+			return
+		}
+		r.ParseForm()
+		if r.Form.Get("code") != "good-code" || r.Form.Get("code_verifier") == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"invalid_grant","error_description":"unexpected code"}`))
+			return
+		}
+		fmt.Fprintf(w, `{"access_token":"browser-session","instance_url":%q}`, client.BaseURL)
+	})
+	client.BaseURL = client.InstanceURL
+	client.SessionID = ""
+
+	statuses := make(chan []int, 1)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := client.LoginBrowser(ctx, BrowserLogin{
+		ClientID: "cli",
+		Addr:     freeAddr(t),
+		Open: func(authURL string) error {
+			u, err := url.Parse(authURL)
+			if err != nil {
+				return err
+			}
+			redirect, state := u.Query().Get("redirect_uri"), u.Query().Get("state")
+
+			go func() {
+				var got []int
+				for _, query := range []string{
+					"code=stale-code&state=old-state",
+					"error=access_denied&error_description=stale",
+					"code=other-code",
+					"code=good-code&state=" + url.QueryEscape(state),
+				} {
+					res, err := http.Get(redirect + "?" + query)
+					if err != nil {
+						t.Error(err)
+						break
+					}
+					res.Body.Close()
+					got = append(got, res.StatusCode)
+				}
+				statuses <- got
+			}()
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []int{http.StatusBadRequest, http.StatusBadRequest, http.StatusBadRequest, http.StatusOK}
+	if got := <-statuses; fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("callback statuses = %v, want %v", got, want)
+	}
+	if client.SessionID != "browser-session" {
+		t.Errorf("session = %q, want browser-session", client.SessionID)
+	}
+}
+
+func TestLoginBrowserDenied(t *testing.T) {
+	client := newTestClient(t, http.NotFound)
+	client.BaseURL = client.InstanceURL
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := client.LoginBrowser(ctx, BrowserLogin{
+		ClientID: "cli",
+		Addr:     freeAddr(t),
+		Open: func(authURL string) error {
+			u, err := url.Parse(authURL)
+			if err != nil {
+				return err
+			}
+			go func() {
+				res, err := http.Get(u.Query().Get("redirect_uri") + "?error=access_denied&error_description=denied&state=" + url.QueryEscape(u.Query().Get("state")))
+				if err == nil {
+					res.Body.Close()
+				}
+			}()
+			return nil
+		},
+	})
+	if err == nil || !strings.Contains(err.Error(), "access_denied") || ctx.Err() != nil {
+		t.Fatalf("err = %v, want the access_denied error before the timeout", err)
+	}
+}
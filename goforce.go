@@ -9,6 +9,8 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/0xArch3r/goforce/api"
 	"github.com/0xArch3r/goforce/types"
@@ -37,6 +39,16 @@ type BaseClient struct {
 	UseToolingAPI bool
 	HttpClient    *http.Client
 	AuthRetry     bool
+	TokenSource   TokenSource
+	Retry         *RetryPolicy
+	Middleware    []api.Middleware
+	APIBudget     float64
+
+	usage   types.APIUsage
+	usageAt time.Time    // when the usage was last reported
+	mu      sync.RWMutex // guards the session while it is renewed and the usage
+	authMu  sync.Mutex   // ensures a single sign in runs at a time
+	relogin func() error
 }
 
 type Client struct {
@@ -67,20 +79,44 @@ func NewClient(opts ...Option) (*Client, error) {
 	return client, nil
 }
 
-// Perform delegates to Transport to execute a request and return a response.
+// Perform delegates to Transport to execute a request and return a response. The request passes through the
+// configured middleware, then the retry policy and finally the session handling. Once the daily API usage reached
+// APIBudget, requests fail with types.ErrAPIBudgetExceeded without being sent until the usage drops again.
 func (c *BaseClient) Perform(req *http.Request) (*api.Response, error) {
-	original_path := req.URL.Path
-	query := req.URL.RawQuery
-	u := fmt.Sprintf("%v/services/data/v%v%v?%v", c.InstanceURL, c.ApiVersion, original_path, query)
+	// The limits resource stays reachable so that the usage can be refreshed.
+	if req.URL.Path != "/limits" {
+		err := c.checkBudget(req.Context())
+		if err != nil {
+			return nil, err
+		}
+	}
 
-	url, err := url.Parse(u)
-	if err != nil {
-		return nil, err
+	req.Header.Set("Content-Type", "application/json")
+
+	// Keep the body around so the request can be replayed after signing in again or on retries.
+	if req.Body != nil && req.GetBody == nil {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		req.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
 	}
-	req.URL = url
-	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", c.SessionID))
-	req.Header.Add("Content-Type", "application/json")
 
+	middleware := append([]api.Middleware{}, c.Middleware...)
+	if c.Retry != nil {
+		middleware = append(middleware, RetryMiddleware(*c.Retry))
+	}
+	middleware = append(middleware, c.authenticate, c.trackUsage)
+
+	return api.Chain(api.TransportFunc(c.roundTrip), middleware...).Perform(req)
+}
+
+// roundTrip sends a fully resolved request.
+func (c *BaseClient) roundTrip(req *http.Request) (*api.Response, error) {
 	// Retrieve the original request.
 	res, err := c.HttpClient.Do(req)
 	if err != nil {
@@ -92,7 +128,106 @@ func (c *BaseClient) Perform(req *http.Request) (*api.Response, error) {
 		Body:       res.Body,
 		Header:     res.Header,
 	}, nil
+}
+
+// authenticate is the middleware resolving requests against the instance of the current session and signing them
+// with it. When salesforce rejects the session, it is renewed and the request replayed once.
+func (c *BaseClient) authenticate(next api.Transport) api.Transport {
+	return api.TransportFunc(func(req *http.Request) (*api.Response, error) {
+		session, instance, err := c.credentials()
+		if err != nil {
+			return nil, err
+		}
+		out, err := c.authorize(req, session, instance)
+		if err != nil {
+			return nil, err
+		}
+		res, err := next.Perform(out)
+		if err != nil {
+			return nil, err
+		}
+
+		if res.StatusCode != http.StatusUnauthorized || !c.canReauthenticate() {
+			return res, nil
+		}
+
+		data, err := io.ReadAll(res.Body)
+		res.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		res.Body = io.NopCloser(bytes.NewReader(data))
 
+		sfErr := types.ParseSalesforceError(res.StatusCode, data)
+		if sfErr.ErrorCode != "INVALID_SESSION_ID" {
+			return res, nil
+		}
+
+		retry, err := c.reauthenticate(session)
+		if err != nil {
+			return nil, err
+		}
+		if !retry {
+			return res, nil
+		}
+
+		session, instance, err = c.credentials()
+		if err != nil {
+			return nil, err
+		}
+		out, err = c.authorize(req, session, instance)
+		if err != nil {
+			return nil, err
+		}
+		return next.Perform(out)
+	})
+}
+
+// authorize returns a copy of req resolved against the instance and signed with the given session, leaving req
+// itself untouched so that it can be sent again.
+func (c *BaseClient) authorize(req *http.Request, session, instance string) (*http.Request, error) {
+	// The escaped path keeps escaped segments such as external IDs containing '/' or '#' intact.
+	original_path := req.URL.EscapedPath()
+	query := req.URL.RawQuery
+	if c.UseToolingAPI && toolingResource(original_path) {
+		original_path = "/tooling" + original_path
+	}
+	u := fmt.Sprintf("%v/services/data/v%v%v?%v", instance, c.ApiVersion, original_path, query)
+	if strings.HasPrefix(original_path, "/services/") {
+		// Paths handed out by salesforce itself, e.g. nextRecordsUrl, are already fully qualified.
+		u = fmt.Sprintf("%v%v?%v", instance, original_path, query)
+	}
+
+	url, err := url.Parse(u)
+	if err != nil {
+		return nil, err
+	}
+
+	out := req.Clone(req.Context())
+	out.URL = url
+	out.Host = ""
+	out.Header.Set("Authorization", fmt.Sprintf("Bearer %s", session))
+	if req.GetBody != nil {
+		out.Body, err = req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// toolingResources are the resources the Tooling API offers as well. Anything else, e.g. /limits or
+// /parameterizedSearch, only exists in the REST API.
+var toolingResources = []string{"/sobjects", "/query", "/queryAll", "/search"}
+
+// toolingResource reports whether path names a resource that WithToolingAPI sends to the Tooling API.
+func toolingResource(path string) bool {
+	for _, resource := range toolingResources {
+		if path == resource || strings.HasPrefix(path, resource+"/") {
+			return true
+		}
+	}
+	return false
 }
 
 // LoginPassword signs into salesforce using password. token is optional if trusted IP is configured.
@@ -166,6 +301,8 @@ func (client *BaseClient) LoginPassword(username, password, token string) error
 	}
 
 	// Now we should all be good and the sessionID can be used to talk to salesforce further.
+	client.mu.Lock()
+	defer client.mu.Unlock()
 	client.SessionID = loginResponse.SessionID
 	client.InstanceURL = parseHost(loginResponse.ServerURL)
 	client.User.Id = loginResponse.UserID
@@ -173,6 +310,10 @@ func (client *BaseClient) LoginPassword(username, password, token string) error
 	client.User.Email = loginResponse.UserEmail
 	client.User.FullName = loginResponse.UserFullName
 	client.Password = password
+	client.relogin = func() error {
+		return client.LoginPassword(username, password, token)
+	}
+	client.TokenSource = nil
 
 	return nil
 }
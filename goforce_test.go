@@ -0,0 +1,217 @@
+package goforce
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/0xArch3r/goforce/types"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc, opts ...Option) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.SessionID = "session"
+	client.InstanceURL = server.URL
+	return client
+}
+
+func TestUpsertEscapesExternalID(t *testing.T) {
+	tests := []struct {
+		id   string
+		want string
+	}{
+		{"A1", "/services/data/v54.0/sobjects/Account/Ext__c/A1"},
+		{"A/1", "/services/data/v54.0/sobjects/Account/Ext__c/A%2F1"},
+		{"A#1", "/services/data/v54.0/sobjects/Account/Ext__c/A%231"},
+		{"A?x", "/services/data/v54.0/sobjects/Account/Ext__c/A%3Fx"},
+		{"A%2F", "/services/data/v54.0/sobjects/Account/Ext__c/A%252F"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			var path, query string
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				path, query = r.URL.EscapedPath(), r.URL.RawQuery
+				w.WriteHeader(http.StatusNoContent)
+			})
+
+			_, err := client.Upsert("Account", types.SObject{"Name": "Acme"}, client.Upsert.ExternalID("Ext__c", tt.id))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if path != tt.want {
+				t.Errorf("path = %v, want %v", path, tt.want)
+			}
+			if query != "" {
+				t.Errorf("query = %v, want none", query)
+			}
+		})
+	}
+}
+
+func TestToolingAPIRouting(t *testing.T) {
+	var paths []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Write([]byte(`{"done":true,"totalSize":0,"records":[],"searchRecords":[]}`))
+	}, WithToolingAPI())
+
+	client.Query.Raw("SELECT Id FROM ApexClass")
+	client.Query.Raw("SELECT Id FROM ApexClass", client.Query.Raw.Tooling())
+	client.Get("ApexClass", "01p000000000001")
+	client.Limits()
+	client.Search("Acme")
+
+	want := []string{
+		"/services/data/v54.0/tooling/query",
+		"/services/data/v54.0/tooling/query",
+		"/services/data/v54.0/tooling/sobjects/ApexClass/01p000000000001",
+		"/services/data/v54.0/limits",
+		"/services/data/v54.0/parameterizedSearch",
+	}
+	if strings.Join(paths, "\n") != strings.Join(want, "\n") {
+		t.Errorf("paths\n%v\nwant\n%v", strings.Join(paths, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+// sessionServer answers SOAP logins with a new session each and accepts requests signed with the latest one only.
+type sessionServer struct {
+	mu       sync.Mutex
+	logins   int
+	valid    string
+	bodies   []string
+	arrivals sync.WaitGroup
+}
+
+func (s *sessionServer) handle(t *testing.T, serverURL func() string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/services/Soap/u/54.0" {
+			s.mu.Lock()
+			s.logins++
+			s.valid = fmt.Sprintf("session-%d", s.logins)
+			session := s.valid
+			s.mu.Unlock()
+			fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8"?><soapenv:Envelope xmlns:soapenv="http://schemas.xmlsoap.org/soap/envelope/"><soapenv:Body><loginResponse><result><serverUrl>%v/services/Soap/u/54.0</serverUrl><sessionId>%v</sessionId><userId>005000000000001</userId></result></loginResponse></soapenv:Body></soapenv:Envelope>`, serverURL(), session)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+		}
+		s.mu.Lock()
+		valid := r.Header.Get("Authorization") == "Bearer "+s.valid
+		if valid {
+			s.bodies = append(s.bodies, string(body))
+		}
+		s.mu.Unlock()
+
+		if !valid {
+			// Hold back the rejections until every request was rejected, so that all of them renew at once.
+			s.arrivals.Done()
+			s.arrivals.Wait()
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`[{"errorCode":"INVALID_SESSION_ID","message":"Session expired or invalid"}]`))
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"001000000000001","success":true,"errors":[]}`))
+	}
+}
+
+func TestAuthRetryConcurrent(t *testing.T) {
+	const workers = 20
+
+	s := &sessionServer{}
+	var client *Client
+	client = newTestClient(t, s.handle(t, func() string { return client.BaseURL }), WithAuthRetry())
+	client.BaseURL = client.InstanceURL
+
+	err := client.LoginPassword("user@example.com", "secret", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Expire the session on the server side.
+	s.mu.Lock()
+	s.valid = "expired"
+	s.mu.Unlock()
+	s.arrivals.Add(workers)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.Create("Account", types.SObject{"Name": fmt.Sprintf("Acme %02d", i)})
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Error(err)
+		}
+	}
+
+	if s.logins != 2 {
+		t.Errorf("logins = %d, want the initial login and a single renewal", s.logins)
+	}
+	sort.Strings(s.bodies)
+	var want []string
+	for i := 0; i < workers; i++ {
+		want = append(want, fmt.Sprintf(`{"Name":"Acme %02d"}`, i))
+	}
+	if strings.Join(s.bodies, "\n") != strings.Join(want, "\n") {
+		t.Errorf("replayed bodies\n%v\nwant\n%v", strings.Join(s.bodies, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestAuthRetryIgnoresOtherUnauthorizedErrors(t *testing.T) {
+	var logins, requests atomic.Int32
+	var client *Client
+	client = newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/services/Soap/u/54.0" {
+			logins.Add(1)
+			fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8"?><soapenv:Envelope xmlns:soapenv="http://schemas.xmlsoap.org/soap/envelope/"><soapenv:Body><loginResponse><result><serverUrl>%v/services/Soap/u/54.0</serverUrl><sessionId>session</sessionId></result></loginResponse></soapenv:Body></soapenv:Envelope>`, client.BaseURL)
+			return
+		}
+		requests.Add(1)
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`[{"errorCode":"API_DISABLED_FOR_ORG","message":"API is not enabled for this Organization or Partner"}]`))
+	}, WithAuthRetry())
+	client.BaseURL = client.InstanceURL
+
+	err := client.LoginPassword("user@example.com", "secret", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.Create("Account", types.SObject{"Name": "Acme"})
+	var sfErr types.SalesforceError
+	if !errors.As(err, &sfErr) || sfErr.ErrorCode != "API_DISABLED_FOR_ORG" {
+		t.Fatalf("err = %v, want API_DISABLED_FOR_ORG", err)
+	}
+	if n := logins.Load(); n != 1 {
+		t.Errorf("logins = %d, want no renewal", n)
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("requests = %d, want no replay", n)
+	}
+}
@@ -0,0 +1,224 @@
+package goforce
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/0xArch3r/goforce/types"
+)
+
+// tokenResponse is the answer of the OAuth 2.0 token endpoint.
+// Ref: https://help.salesforce.com/s/articleView?id=sf.remoteaccess_oauth_endpoints.htm
+type tokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	InstanceURL  string `json:"instance_url"`
+	ID           string `json:"id"`
+	IssuedAt     string `json:"issued_at"`
+	TokenType    string `json:"token_type"`
+	Scope        string `json:"scope"`
+}
+
+// identity is the subset of the identity URL response used to fill in BaseClient.User.
+type identity struct {
+	UserID      string `json:"user_id"`
+	Username    string `json:"username"`
+	DisplayName string `json:"display_name"`
+	Email       string `json:"email"`
+}
+
+// LoginJWT signs into salesforce with the OAuth 2.0 JWT bearer flow. privateKeyPEM is the PEM encoded RSA key
+// whose certificate is uploaded to the connected app identified by clientID.
+// Ref: https://help.salesforce.com/s/articleView?id=sf.remoteaccess_oauth_jwt_flow.htm
+func (client *BaseClient) LoginJWT(clientID, username string, privateKeyPEM []byte) error {
+	key, err := parsePrivateKey(privateKeyPEM)
+	if err != nil {
+		return err
+	}
+
+	claims := map[string]interface{}{
+		"iss": clientID,
+		"sub": username,
+		"aud": client.BaseURL,
+		"exp": time.Now().Add(3 * time.Minute).Unix(),
+	}
+	assertion, err := signJWT(key, claims)
+	if err != nil {
+		return err
+	}
+
+	tok, err := client.requestToken(url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {assertion},
+	})
+	if err != nil {
+		return err
+	}
+
+	return client.applyToken(tok, func() error {
+		return client.LoginJWT(clientID, username, privateKeyPEM)
+	})
+}
+
+// LoginClientCredentials signs into salesforce with the OAuth 2.0 client credentials flow, acting as the run-as user
+// configured on the connected app. BaseURL must point to the My Domain of the org, as login.salesforce.com does not
+// support this flow.
+// Ref: https://help.salesforce.com/s/articleView?id=sf.remoteaccess_oauth_client_credentials_flow.htm
+func (client *BaseClient) LoginClientCredentials(clientID, clientSecret string) error {
+	tok, err := client.requestToken(url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {clientID},
+		"client_secret": {clientSecret},
+	})
+	if err != nil {
+		return err
+	}
+
+	return client.applyToken(tok, func() error {
+		return client.LoginClientCredentials(clientID, clientSecret)
+	})
+}
+
+// requestToken posts form to the token endpoint of BaseURL.
+func (client *BaseClient) requestToken(form url.Values) (*tokenResponse, error) {
+	u := fmt.Sprintf("%s/services/oauth2/token", client.BaseURL)
+	req, err := http.NewRequest(http.MethodPost, u, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Add("Accept", "application/json")
+
+	resp, err := client.HttpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respData, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, types.ParseSalesforceError(resp.StatusCode, respData)
+	}
+
+	tok := &tokenResponse{}
+	err = json.Unmarshal(respData, tok)
+	if err != nil {
+		return nil, err
+	}
+	if tok.AccessToken == "" {
+		return nil, types.ErrAuthentication
+	}
+	return tok, nil
+}
+
+// applyToken stores the session of tok, looks up the signed in user and remembers relogin for WithAuthRetry.
+func (client *BaseClient) applyToken(tok *tokenResponse, relogin func() error) error {
+	var user identity
+	if tok.ID != "" {
+		err := client.fetchIdentity(tok.ID, tok.AccessToken, &user)
+		if err != nil {
+			return err
+		}
+	}
+
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	client.SessionID = tok.AccessToken
+	client.InstanceURL = strings.TrimSuffix(tok.InstanceURL, "/")
+	client.User.Id = user.UserID
+	client.User.Name = user.Username
+	client.User.Email = user.Email
+	client.User.FullName = user.DisplayName
+	client.relogin = relogin
+	client.TokenSource = nil
+
+	return nil
+}
+
+// fetchIdentity reads the identity URL returned alongside an access token.
+// Ref: https://help.salesforce.com/s/articleView?id=sf.remoteaccess_using_openid.htm
+func (client *BaseClient) fetchIdentity(idURL, accessToken string, user *identity) error {
+	req, err := http.NewRequest(http.MethodGet, idURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+	req.Header.Add("Accept", "application/json")
+
+	resp, err := client.HttpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respData, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return types.ParseSalesforceError(resp.StatusCode, respData)
+	}
+
+	return json.Unmarshal(respData, user)
+}
+
+// signJWT encodes claims as a JWT signed with RS256.
+func signJWT(key *rsa.PrivateKey, claims map[string]interface{}) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(unsigned))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// parsePrivateKey decodes a PKCS#1 or PKCS#8 PEM encoded RSA private key.
+func parsePrivateKey(privateKeyPEM []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(privateKeyPEM)
+	if block == nil {
+		return nil, errors.New("no PEM encoded private key found")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("private key is not an RSA key")
+	}
+	return key, nil
+}
@@ -1,8 +1,11 @@
 package goforce
 
 import (
+	"errors"
 	"net/http"
 	"strings"
+
+	"github.com/0xArch3r/goforce/api"
 )
 
 type Option func(client *Client) error
@@ -36,9 +39,60 @@ func WithHttpClient(hc *http.Client) Option {
 	}
 }
 
+// WithAuthRetry signs in again with the credentials of the last login when salesforce rejects the session as
+// expired (INVALID_SESSION_ID), then replays the original request.
 func WithAuthRetry() Option {
 	return func(client *Client) error {
 		client.AuthRetry = true
 		return nil
 	}
 }
+
+// WithToolingAPI sends the record, query and search requests of the client to the Tooling API instead of the REST
+// API. Resources the Tooling API lacks, such as limits, keep using the REST API.
+func WithToolingAPI() Option {
+	return func(client *Client) error {
+		client.UseToolingAPI = true
+		return nil
+	}
+}
+
+// WithRetry retries requests that fail with transient errors according to policy. The zero RetryPolicy uses the
+// defaults.
+func WithRetry(policy RetryPolicy) Option {
+	return func(client *Client) error {
+		client.Retry = &policy
+		return nil
+	}
+}
+
+// WithMiddleware wraps every request in the given middleware, the first one being the outermost. Middleware sees
+// requests before they are resolved against the instance and signed, so paths are relative to /services/data/vXX.X.
+func WithMiddleware(middleware ...api.Middleware) Option {
+	return func(client *Client) error {
+		client.Middleware = append(client.Middleware, middleware...)
+		return nil
+	}
+}
+
+// WithAPIBudget makes requests fail fast with types.ErrAPIBudgetExceeded once the daily API usage reported by
+// salesforce reaches percent of the allocation. While the budget is exceeded the usage is fetched from the limits
+// resource at most once a minute, and requests go through again as soon as it dropped below percent.
+func WithAPIBudget(percent float64) Option {
+	return func(client *Client) error {
+		if percent <= 0 || percent > 100 {
+			return errors.New("api budget must be between 0 and 100 percent")
+		}
+		client.APIBudget = percent
+		return nil
+	}
+}
+
+// WithTokenSource makes the client take its access token from ts instead of a login call. The token is refreshed
+// through ts whenever salesforce rejects it.
+func WithTokenSource(ts TokenSource) Option {
+	return func(client *Client) error {
+		client.TokenSource = ts
+		return nil
+	}
+}
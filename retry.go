@@ -0,0 +1,176 @@
+package goforce
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/0xArch3r/goforce/api"
+	"github.com/0xArch3r/goforce/types"
+)
+
+const (
+	DefaultRetryAttempts  = 4
+	DefaultRetryBaseDelay = 500 * time.Millisecond
+	DefaultRetryMaxDelay  = 30 * time.Second
+)
+
+// RetryPolicy configures how requests failing with transient errors are retried: 503 responses and the
+// REQUEST_LIMIT_EXCEEDED and UNABLE_TO_LOCK_ROW error codes, which salesforce returns without applying the request,
+// as well as network errors, 502 and 504 responses for idempotent methods. Attempts are spaced with jittered
+// exponential backoff unless salesforce asks for a delay through Retry-After, which is capped at MaxDelay.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts including the first one. Defaults to DefaultRetryAttempts.
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry, doubled for every following one. Defaults to
+	// DefaultRetryBaseDelay.
+	BaseDelay time.Duration
+	// MaxDelay caps the computed backoff and the delay requested through Retry-After. Defaults to
+	// DefaultRetryMaxDelay.
+	MaxDelay time.Duration
+	// RetryPost also retries POST requests after network errors, 502 and 504 responses. These may have been applied
+	// before failing, so a retried Create can insert the record twice.
+	RetryPost bool
+}
+
+// retryableErrorCodes are the salesforce error codes that are expected to succeed when retried later.
+var retryableErrorCodes = map[string]bool{
+	"REQUEST_LIMIT_EXCEEDED": true,
+	"UNABLE_TO_LOCK_ROW":     true,
+}
+
+// RetryMiddleware retries requests according to policy. WithRetry installs it right before the session handling;
+// add it through WithMiddleware instead to control its position relative to other middleware. The request body must
+// be replayable through req.GetBody, which Perform guarantees.
+func RetryMiddleware(policy RetryPolicy) api.Middleware {
+	return func(next api.Transport) api.Transport {
+		return api.TransportFunc(func(req *http.Request) (*api.Response, error) {
+			return policy.perform(req, next)
+		})
+	}
+}
+
+// perform sends req through next until it succeeds, fails permanently or the attempts are exhausted.
+func (p *RetryPolicy) perform(req *http.Request, next api.Transport) (*api.Response, error) {
+	maxAttempts := p.MaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = DefaultRetryAttempts
+	}
+
+	ctx := req.Context()
+	for attempt := 1; ; attempt++ {
+		res, err := next.Perform(req)
+		if attempt >= maxAttempts || !p.retryable(req, res, err) {
+			return res, err
+		}
+
+		delay := p.backoff(attempt)
+		if res != nil {
+			if after, ok := retryAfter(res.Header); ok {
+				delay = min(after, p.maxDelay())
+			}
+		}
+		// Waiting is pointless when the caller gives up before the next attempt could be sent.
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return res, err
+		}
+		if res != nil {
+			io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// retryable reports whether the outcome of an attempt is a transient failure. Error bodies are buffered so that the
+// response can still be read by the caller.
+func (p *RetryPolicy) retryable(req *http.Request, res *api.Response, err error) bool {
+	if err != nil {
+		// Errors caused by the caller giving up or the client being misconfigured won't go away by retrying.
+		return p.replayable(req) && req.Context().Err() == nil && !errors.Is(err, types.ErrAuthentication)
+	}
+
+	switch res.StatusCode {
+	case http.StatusServiceUnavailable:
+		return true
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		return p.replayable(req)
+	}
+	if !res.IsError() {
+		return false
+	}
+
+	data, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	res.Body = io.NopCloser(bytes.NewReader(data))
+	if err != nil {
+		return false
+	}
+	return retryableErrorCodes[types.ParseSalesforceError(res.StatusCode, data).ErrorCode]
+}
+
+// replayable reports whether req may be sent again although an earlier attempt may have been applied. Reads,
+// deletes and updates addressed by Id or external ID leave the same result when repeated, inserts don't.
+func (p *RetryPolicy) replayable(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	case http.MethodPost:
+		return p.RetryPost
+	}
+	return false
+}
+
+// maxDelay returns the longest delay before a retry.
+func (p *RetryPolicy) maxDelay() time.Duration {
+	if p.MaxDelay <= 0 {
+		return DefaultRetryMaxDelay
+	}
+	return p.MaxDelay
+}
+
+// backoff returns the jittered delay before the retry following the given attempt.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	base, max := p.BaseDelay, p.maxDelay()
+	if base <= 0 {
+		base = DefaultRetryBaseDelay
+	}
+
+	delay := base << (attempt - 1)
+	if delay > max || delay <= 0 {
+		delay = max
+	}
+	// Keep half of the delay and randomize the rest to spread out clients retrying at the same time.
+	half := delay / 2
+	return half + rand.N(half+1)
+}
+
+// retryAfter parses the Retry-After header, given either in seconds or as HTTP date.
+func retryAfter(header http.Header) (time.Duration, bool) {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		delay := time.Until(at)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+	return 0, false
+}
@@ -0,0 +1,91 @@
+package goforce
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/0xArch3r/goforce/types"
+)
+
+func TestRetryMethods(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		body      string
+		post      bool
+		retryPost bool
+		want      int32
+	}{
+		{"get on 502", http.StatusBadGateway, "", false, false, 3},
+		{"post on 502", http.StatusBadGateway, "", true, false, 1},
+		{"post on 504 with RetryPost", http.StatusGatewayTimeout, "", true, true, 3},
+		{"post on 503", http.StatusServiceUnavailable, "", true, false, 3},
+		{"post on row lock", http.StatusBadRequest, `[{"errorCode":"UNABLE_TO_LOCK_ROW","message":"locked"}]`, true, false, 3},
+		{"post on other error", http.StatusBadRequest, `[{"errorCode":"INVALID_FIELD","message":"bad"}]`, true, false, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				attempts.Add(1)
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}, WithRetry(RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, RetryPost: tt.retryPost}))
+
+			if tt.post {
+				client.Create("Account", types.SObject{"Name": "Acme"})
+			} else {
+				client.Get("Account", "001000000000001")
+			}
+			if got := attempts.Load(); got != tt.want {
+				t.Errorf("attempts = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryCapsRetryAfter(t *testing.T) {
+	var attempts atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}, WithRetry(RetryPolicy{MaxAttempts: 2, MaxDelay: 10 * time.Millisecond}))
+
+	start := time.Now()
+	client.Get("Account", "001000000000001")
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("waited %v despite MaxDelay", elapsed)
+	}
+	if got := attempts.Load(); got != 2 {
+		t.Errorf("attempts = %d, want 2", got)
+	}
+}
+
+func TestRetryGivesUpBeforeDeadline(t *testing.T) {
+	var attempts atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.Header().Set("Retry-After", "20")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}, WithRetry(RetryPolicy{MaxAttempts: 2}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.Get("Account", "001000000000001", client.Get.WithContext(ctx))
+	if err == nil {
+		t.Fatal("want the 503 error")
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("waited %v although the deadline could not be met", elapsed)
+	}
+	if got := attempts.Load(); got != 1 {
+		t.Errorf("attempts = %d, want 1", got)
+	}
+}
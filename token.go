@@ -0,0 +1,158 @@
+package goforce
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/0xArch3r/goforce/types"
+)
+
+// expiryDelta renews tokens slightly ahead of their expiry so that they don't run out in flight.
+const expiryDelta = 30 * time.Second
+
+// Token is an OAuth 2.0 access token together with what is needed to renew it.
+type Token struct {
+	AccessToken  string
+	RefreshToken string
+	InstanceURL  string
+	// ID is the identity URL returned alongside the access token.
+	ID string
+	// Expiry is the time the access token stops working. Salesforce does not report it, so the zero value means the
+	// token is only renewed once it is rejected.
+	Expiry time.Time
+}
+
+// Valid reports whether the token holds an access token that has not expired yet.
+func (t *Token) Valid() bool {
+	if t == nil || t.AccessToken == "" {
+		return false
+	}
+	return t.Expiry.IsZero() || time.Now().Add(expiryDelta).Before(t.Expiry)
+}
+
+// TokenSource supplies the access token used for every request.
+type TokenSource interface {
+	// Token returns a valid token, renewing it first if it has expired.
+	Token() (*Token, error)
+	// Refresh renews the token unconditionally, e.g. after salesforce rejected it.
+	Refresh() (*Token, error)
+}
+
+// LoginToken signs into salesforce with a token obtained elsewhere, e.g. through the web server flow of a web app.
+// clientID and clientSecret identify the connected app that issued tok; the secret may be empty for apps that don't
+// require one. When tok carries a refresh token it is renewed through /services/oauth2/token once it expires or is
+// rejected, and onRefresh, if not nil, receives every renewed token so that rotated tokens can be persisted.
+// Ref: https://help.salesforce.com/s/articleView?id=sf.remoteaccess_oauth_refresh_token_flow.htm
+func (client *BaseClient) LoginToken(clientID, clientSecret string, tok Token, onRefresh func(Token)) error {
+	ts := &refreshTokenSource{
+		client:       client,
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		onRefresh:    onRefresh,
+		token:        tok,
+	}
+
+	current, err := ts.Token()
+	if err != nil {
+		return err
+	}
+
+	var user identity
+	if current.ID != "" {
+		err = client.fetchIdentity(current.ID, current.AccessToken, &user)
+		if err != nil {
+			return err
+		}
+	}
+
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	client.TokenSource = ts
+	client.SessionID = current.AccessToken
+	client.InstanceURL = strings.TrimSuffix(current.InstanceURL, "/")
+	client.User.Id = user.UserID
+	client.User.Name = user.Username
+	client.User.Email = user.Email
+	client.User.FullName = user.DisplayName
+	client.relogin = nil
+
+	return nil
+}
+
+// refreshTokenSource renews its token with the OAuth 2.0 refresh token flow.
+type refreshTokenSource struct {
+	client       *BaseClient
+	clientID     string
+	clientSecret string
+	onRefresh    func(Token)
+
+	mu    sync.Mutex
+	token Token
+}
+
+// Token returns the current token, refreshing it when it has expired.
+func (ts *refreshTokenSource) Token() (*Token, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if ts.token.Valid() {
+		tok := ts.token
+		return &tok, nil
+	}
+	return ts.refresh()
+}
+
+// Refresh renews the token.
+func (ts *refreshTokenSource) Refresh() (*Token, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	return ts.refresh()
+}
+
+func (ts *refreshTokenSource) refresh() (*Token, error) {
+	if ts.token.RefreshToken == "" {
+		return nil, fmt.Errorf("%w: no refresh token to renew the access token", types.ErrAuthentication)
+	}
+
+	form := url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {ts.token.RefreshToken},
+		"client_id":     {ts.clientID},
+	}
+	if ts.clientSecret != "" {
+		form.Set("client_secret", ts.clientSecret)
+	}
+
+	resp, err := ts.client.requestToken(form)
+	if err != nil {
+		return nil, err
+	}
+
+	ts.token.AccessToken = resp.AccessToken
+	ts.token.Expiry = time.Time{}
+	// Salesforce only returns a refresh token when rotation is enabled for the connected app.
+	if resp.RefreshToken != "" {
+		ts.token.RefreshToken = resp.RefreshToken
+	}
+	if resp.InstanceURL != "" {
+		ts.token.InstanceURL = resp.InstanceURL
+	}
+	if resp.ID != "" {
+		ts.token.ID = resp.ID
+	}
+
+	ts.client.mu.Lock()
+	ts.client.SessionID = ts.token.AccessToken
+	ts.client.InstanceURL = strings.TrimSuffix(ts.token.InstanceURL, "/")
+	ts.client.mu.Unlock()
+
+	tok := ts.token
+	if ts.onRefresh != nil {
+		ts.onRefresh(tok)
+	}
+	return &tok, nil
+}
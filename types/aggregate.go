@@ -0,0 +1,62 @@
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// AggregateResult is a record returned by a query using aggregate functions such as SUM() or GROUP BY. Values are
+// keyed by their alias; aggregate functions without alias are named expr0, expr1, ... in query order.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.soql_sosl.meta/soql_sosl/sforce_api_calls_soql_select_groupby_alias.htm
+type AggregateResult map[string]interface{}
+
+// Aggregates returns the records of an aggregate query. Note that a plain COUNT() returns no records at all; its
+// result is TotalSize.
+func (r *QueryResult) Aggregates() []AggregateResult {
+	res := make([]AggregateResult, len(r.Records))
+	for i, record := range r.Records {
+		res[i] = AggregateResult(record)
+	}
+	return res
+}
+
+// Value returns the raw value of alias.
+func (r AggregateResult) Value(alias string) interface{} {
+	return r[alias]
+}
+
+// Expr returns the raw value of the i-th unaliased aggregate function.
+func (r AggregateResult) Expr(i int) interface{} {
+	return r[fmt.Sprintf("expr%d", i)]
+}
+
+// String returns the value of alias as string. Empty string is returned if the value is null or not a string.
+func (r AggregateResult) String(alias string) string {
+	value, _ := r[alias].(string)
+	return value
+}
+
+// Float returns the value of alias as float64. Zero is returned if the value is null or not a number.
+func (r AggregateResult) Float(alias string) float64 {
+	switch value := r[alias].(type) {
+	case float64:
+		return value
+	case string:
+		f, _ := strconv.ParseFloat(value, 64)
+		return f
+	}
+	return 0
+}
+
+// Int returns the value of alias as int64, e.g. for COUNT(Id). Zero is returned if the value is null or not a number.
+func (r AggregateResult) Int(alias string) int64 {
+	return int64(r.Float(alias))
+}
+
+// Time returns the value of alias as time, e.g. for MAX(CreatedDate). The zero time is returned if the value is null
+// or not a date.
+func (r AggregateResult) Time(alias string) time.Time {
+	t, _ := parseDateTime(r.String(alias))
+	return t
+}
@@ -0,0 +1,69 @@
+package types
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// DateFormat is the layout salesforce uses for date fields.
+const DateFormat = "2006-01-02"
+
+// dateTimeFormats are the layouts salesforce uses for datetime fields, the first one being the canonical form.
+var dateTimeFormats = []string{
+	"2006-01-02T15:04:05.000-0700",
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05-0700",
+}
+
+// Date is a calendar date without time of day, as stored by salesforce date fields.
+type Date struct {
+	time.Time
+}
+
+// NewDate returns the Date of the given day.
+func NewDate(year int, month time.Month, day int) Date {
+	return Date{time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+}
+
+// String returns the date in the salesforce format, e.g. 2024-01-31.
+func (d Date) String() string {
+	return d.Format(DateFormat)
+}
+
+// MarshalJSON encodes the date in the salesforce format.
+func (d Date) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON decodes a date in the salesforce format.
+func (d *Date) UnmarshalJSON(data []byte) error {
+	var value *string
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return err
+	}
+	if value == nil {
+		*d = Date{}
+		return nil
+	}
+	t, err := time.Parse(DateFormat, *value)
+	if err != nil {
+		return err
+	}
+	*d = Date{t}
+	return nil
+}
+
+// parseDateTime parses a datetime as returned by salesforce, accepting plain dates as well.
+func parseDateTime(value string) (time.Time, error) {
+	for _, layout := range dateTimeFormats {
+		t, err := time.Parse(layout, value)
+		if err == nil {
+			return t, nil
+		}
+	}
+	return time.Parse(DateFormat, value)
+}
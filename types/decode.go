@@ -0,0 +1,271 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	timeType    = reflect.TypeOf(time.Time{})
+	dateType    = reflect.TypeOf(Date{})
+	sobjectType = reflect.TypeOf(SObject{})
+)
+
+// Decode maps the records into v, which must point to a slice of structs or struct pointers. See SObject.Decode for
+// how fields are matched.
+func (r *QueryResult) Decode(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Slice {
+		return errors.New("decode target must be a non-nil pointer to a slice")
+	}
+
+	records := make([]interface{}, len(r.Records))
+	for i, record := range r.Records {
+		records[i] = map[string]interface{}(record)
+	}
+	return decodeValue(records, rv.Elem(), "")
+}
+
+// Decode maps the SObject into the struct v points to. Fields are matched by their sf tag, e.g. `sf:"Name"`, or by
+// their Go name when untagged; `sf:"-"` skips a field. Relationship fields decode into nested structs, child
+// subqueries into slices, date and datetime fields into time.Time or Date, and null values leave the zero value.
+func (obj *SObject) Decode(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return errors.New("decode target must be a non-nil pointer")
+	}
+	return decodeValue(map[string]interface{}(*obj), rv.Elem(), "")
+}
+
+// fieldName returns the salesforce field name of a struct field, or "" if the field is skipped.
+func fieldName(field reflect.StructField) string {
+	if !field.IsExported() {
+		return ""
+	}
+	tag, ok := field.Tag.Lookup("sf")
+	if !ok {
+		return field.Name
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+// lookup returns the value of key in m, falling back to a case-insensitive match as salesforce field names are.
+func lookup(m map[string]interface{}, key string) (interface{}, bool) {
+	if value, ok := m[key]; ok {
+		return value, true
+	}
+	for k, value := range m {
+		if strings.EqualFold(k, key) {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+// decodeValue stores src, a value produced by encoding/json, in dst. path names the field for error messages.
+func decodeValue(src interface{}, dst reflect.Value, path string) error {
+	if src == nil {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+
+	switch dst.Type() {
+	case timeType, dateType:
+		s, ok := src.(string)
+		if !ok {
+			return decodeError(src, dst, path)
+		}
+		t, err := parseDateTime(s)
+		if err != nil {
+			return fmt.Errorf("decode %v: %w", path, err)
+		}
+		if dst.Type() == dateType {
+			dst.Set(reflect.ValueOf(Date{t}))
+		} else {
+			dst.Set(reflect.ValueOf(t))
+		}
+		return nil
+	case sobjectType:
+		m, ok := src.(map[string]interface{})
+		if !ok {
+			return decodeError(src, dst, path)
+		}
+		dst.Set(reflect.ValueOf(SObject(m)))
+		return nil
+	}
+
+	switch dst.Kind() {
+	case reflect.Pointer:
+		elem := reflect.New(dst.Type().Elem())
+		err := decodeValue(src, elem.Elem(), path)
+		if err != nil {
+			return err
+		}
+		dst.Set(elem)
+		return nil
+	case reflect.Interface:
+		if dst.NumMethod() == 0 {
+			dst.Set(reflect.ValueOf(src))
+			return nil
+		}
+	case reflect.Struct:
+		m, ok := src.(map[string]interface{})
+		if !ok {
+			return decodeError(src, dst, path)
+		}
+		return decodeStruct(m, dst, path)
+	case reflect.Slice:
+		// Child relationships are returned as nested query results.
+		if m, ok := src.(map[string]interface{}); ok {
+			src = m["records"]
+			if src == nil {
+				dst.Set(reflect.Zero(dst.Type()))
+				return nil
+			}
+		}
+		items, ok := src.([]interface{})
+		if !ok {
+			return decodeError(src, dst, path)
+		}
+		slice := reflect.MakeSlice(dst.Type(), len(items), len(items))
+		for i, item := range items {
+			err := decodeValue(item, slice.Index(i), fmt.Sprintf("%v[%d]", path, i))
+			if err != nil {
+				return err
+			}
+		}
+		dst.Set(slice)
+		return nil
+	case reflect.String:
+		if s, ok := src.(string); ok {
+			dst.SetString(s)
+			return nil
+		}
+	case reflect.Bool:
+		if b, ok := src.(bool); ok {
+			dst.SetBool(b)
+			return nil
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		switch n := src.(type) {
+		case float64:
+			// Like encoding/json, refuse to drop fractions or wrap around, e.g. for currency or percent fields.
+			i := int64(n)
+			if float64(i) != n || dst.OverflowInt(i) {
+				return numberError(n, dst, path)
+			}
+			dst.SetInt(i)
+			return nil
+		case string:
+			i, err := strconv.ParseInt(n, 10, 64)
+			if err == nil {
+				dst.SetInt(i)
+				return nil
+			}
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		switch n := src.(type) {
+		case float64:
+			u := uint64(n)
+			if n < 0 || float64(u) != n || dst.OverflowUint(u) {
+				return numberError(n, dst, path)
+			}
+			dst.SetUint(u)
+			return nil
+		case string:
+			u, err := strconv.ParseUint(n, 10, 64)
+			if err == nil {
+				dst.SetUint(u)
+				return nil
+			}
+		}
+	case reflect.Float32, reflect.Float64:
+		switch n := src.(type) {
+		case float64:
+			dst.SetFloat(n)
+			return nil
+		case string:
+			f, err := strconv.ParseFloat(n, 64)
+			if err == nil {
+				dst.SetFloat(f)
+				return nil
+			}
+		}
+	}
+
+	// Leave anything else, e.g. types implementing json.Unmarshaler, to encoding/json.
+	if dst.CanAddr() {
+		data, err := json.Marshal(src)
+		if err != nil {
+			return err
+		}
+		err = json.Unmarshal(data, dst.Addr().Interface())
+		if err == nil {
+			return nil
+		}
+	}
+	return decodeError(src, dst, path)
+}
+
+// decodeStruct stores the fields of m in the struct dst.
+func decodeStruct(m map[string]interface{}, dst reflect.Value, path string) error {
+	t := dst.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		// Untagged embedded structs share the fields of the record.
+		if field.Anonymous && field.Type.Kind() == reflect.Struct && field.Tag.Get("sf") == "" {
+			err := decodeStruct(m, dst.Field(i), path)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		name := fieldName(field)
+		if name == "" {
+			continue
+		}
+		value, ok := lookup(m, name)
+		if !ok {
+			continue
+		}
+
+		fieldPath := name
+		if path != "" {
+			fieldPath = path + "." + name
+		}
+		err := decodeValue(value, dst.Field(i), fieldPath)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// numberError reports a number that does not fit into dst without losing its value.
+func numberError(n float64, dst reflect.Value, path string) error {
+	if path == "" {
+		return fmt.Errorf("cannot decode number %v into %v", n, dst.Type())
+	}
+	return fmt.Errorf("cannot decode %v: number %v does not fit into %v", path, n, dst.Type())
+}
+
+func decodeError(src interface{}, dst reflect.Value, path string) error {
+	if path == "" {
+		return fmt.Errorf("cannot decode %T into %v", src, dst.Type())
+	}
+	return fmt.Errorf("cannot decode %v of type %T into %v", path, src, dst.Type())
+}
@@ -0,0 +1,50 @@
+package types
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDecodeIntegers(t *testing.T) {
+	type record struct {
+		N  int  `sf:"N"`
+		U  uint `sf:"U"`
+		I8 int8 `sf:"I8"`
+		P  *int `sf:"P"`
+	}
+
+	tests := []struct {
+		name string
+		obj  SObject
+		want record
+		err  string
+	}{
+		{"whole numbers", SObject{"N": 12.0, "U": 3.0, "I8": -5.0}, record{N: 12, U: 3, I8: -5}, ""},
+		{"numeric strings", SObject{"N": "12", "U": "3"}, record{N: 12, U: 3}, ""},
+		{"null", SObject{"N": nil, "P": nil}, record{}, ""},
+		{"fraction", SObject{"N": 12.7}, record{}, "N: number 12.7 does not fit into int"},
+		{"fraction behind pointer", SObject{"P": 0.5}, record{}, "P: number 0.5 does not fit into int"},
+		{"negative unsigned", SObject{"U": -1.0}, record{}, "U: number -1 does not fit into uint"},
+		{"overflow", SObject{"I8": 300.0}, record{}, "I8: number 300 does not fit into int8"},
+		{"beyond int64", SObject{"N": 1e20}, record{}, "does not fit into int"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got record
+			err := tt.obj.Decode(&got)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("err = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
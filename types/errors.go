@@ -13,6 +13,9 @@ var (
 
 	// ErrAuthentication is returned when authentication failed.
 	ErrAuthentication = errors.New("authentication failure")
+
+	// ErrAPIBudgetExceeded is returned without contacting salesforce once the daily API usage passed the configured budget.
+	ErrAPIBudgetExceeded = errors.New("api usage budget exceeded")
 )
 
 type jsonError []struct {
@@ -20,6 +23,11 @@ type jsonError []struct {
 	ErrorCode string `json:"errorCode"`
 }
 
+type oauthError struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
 type xmlError struct {
 	Message   string `xml:"Body>Fault>faultstring"`
 	ErrorCode string `xml:"Body>Fault>faultcode"`
@@ -40,7 +48,7 @@ func (err SalesforceError) Error() string {
 func ParseSalesforceError(statusCode int, responseBody []byte) SalesforceError {
 	jsonError := jsonError{}
 	err := json.Unmarshal(responseBody, &jsonError)
-	if err == nil {
+	if err == nil && len(jsonError) > 0 {
 		return SalesforceError{
 			Message: fmt.Sprintf(
 				"Error: http code: %v Error Message:  %v Error Code: %v",
@@ -52,6 +60,20 @@ func ParseSalesforceError(statusCode int, responseBody []byte) SalesforceError {
 		}
 	}
 
+	oauthError := oauthError{}
+	err = json.Unmarshal(responseBody, &oauthError)
+	if err == nil && oauthError.Error != "" {
+		return SalesforceError{
+			Message: fmt.Sprintf(
+				"Error: http code: %v Error Message:  %v Error Code: %v",
+				statusCode, oauthError.ErrorDescription, oauthError.Error,
+			),
+			HttpCode:     statusCode,
+			ErrorCode:    oauthError.Error,
+			ErrorMessage: oauthError.ErrorDescription,
+		}
+	}
+
 	xmlError := xmlError{}
 	err = xml.Unmarshal(responseBody, &xmlError)
 	if err == nil {
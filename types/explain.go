@@ -0,0 +1,39 @@
+package types
+
+// ExplainResult lists the query plans salesforce considered for a query, cheapest first.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.214.0.api_rest.meta/api_rest/dome_query_explain.htm
+type ExplainResult struct {
+	Plans       []QueryPlan `json:"plans"`
+	SourceQuery string      `json:"sourceQuery"`
+}
+
+// QueryPlan describes one way of executing a query.
+type QueryPlan struct {
+	Cardinality          int             `json:"cardinality"`
+	Fields               []string        `json:"fields"`
+	LeadingOperationType string          `json:"leadingOperationType"`
+	Notes                []QueryPlanNote `json:"notes"`
+	RelativeCost         float64         `json:"relativeCost"`
+	SObjectCardinality   int             `json:"sobjectCardinality"`
+	SObjectType          string          `json:"sobjectType"`
+}
+
+// QueryPlanNote explains why an index could not be used.
+type QueryPlanNote struct {
+	Description   string   `json:"description"`
+	Fields        []string `json:"fields"`
+	TableEnumOrID string   `json:"tableEnumOrId"`
+}
+
+// Best returns the plan salesforce will use, or nil if there is none.
+func (r *ExplainResult) Best() *QueryPlan {
+	if len(r.Plans) == 0 {
+		return nil
+	}
+	return &r.Plans[0]
+}
+
+// Selective reports whether the plan is cheaper than a full table scan, i.e. its relative cost is below 1.
+func (p QueryPlan) Selective() bool {
+	return p.RelativeCost < 1
+}
@@ -0,0 +1,101 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// maxRelationshipDepth is the number of parent relationship levels salesforce allows in a query.
+const maxRelationshipDepth = 5
+
+// FieldsOf derives the field list of a SOQL query from the sf tags of a struct, matching the rules of SObject.Decode.
+// v may be a struct, a slice of structs or a pointer to either. Nested structs become dotted relationship fields such
+// as Account.Name, and slices of structs become child subqueries such as (SELECT Id FROM Contacts).
+func FieldsOf(v interface{}) ([]string, error) {
+	t := reflect.TypeOf(v)
+	for t != nil && (t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice) {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, errors.New("fields can only be derived from a struct")
+	}
+
+	fields := structFields(t, "", false)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("%v has no fields to select", t)
+	}
+	return fields, nil
+}
+
+// structFields lists the fields of t, each prefixed with prefix. child is set within a child subquery, which cannot
+// hold further subqueries. Self-referencing types such as Account.Parent are expanded up to maxRelationshipDepth.
+func structFields(t reflect.Type, prefix string, child bool) []string {
+	var fields []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		if field.Anonymous && field.Type.Kind() == reflect.Struct && field.Tag.Get("sf") == "" {
+			fields = append(fields, structFields(field.Type, prefix, child)...)
+			continue
+		}
+
+		name := fieldName(field)
+		if name == "" || name == sobjectAttributesKey {
+			continue
+		}
+
+		ft := field.Type
+		for ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+
+		switch {
+		case isScalar(ft):
+			fields = append(fields, prefix+name)
+		case ft.Kind() == reflect.Struct:
+			if strings.Count(prefix, ".") >= maxRelationshipDepth {
+				continue
+			}
+			fields = append(fields, structFields(ft, prefix+name+".", child)...)
+		case ft.Kind() == reflect.Slice && isRecord(ft.Elem()):
+			// Child subqueries are only allowed on the queried object itself, not on parents or within other children.
+			if prefix != "" || child {
+				continue
+			}
+			elem := ft.Elem()
+			for elem.Kind() == reflect.Pointer {
+				elem = elem.Elem()
+			}
+			children := structFields(elem, "", true)
+			if len(children) > 0 {
+				fields = append(fields, fmt.Sprintf("(SELECT %v FROM %v)", strings.Join(children, ", "), name))
+			}
+		}
+	}
+	return fields
+}
+
+// isScalar reports whether values of t are held in a single field.
+func isScalar(t reflect.Type) bool {
+	switch t {
+	case timeType, dateType:
+		return true
+	case sobjectType:
+		return false
+	}
+	switch t.Kind() {
+	case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array, reflect.Chan, reflect.Func:
+		return false
+	}
+	return true
+}
+
+// isRecord reports whether t, possibly a pointer, is a struct describing a record.
+func isRecord(t reflect.Type) bool {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct && t != timeType && t != dateType
+}
@@ -0,0 +1,89 @@
+package types
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+type fieldsComment struct {
+	CommentBody string `sf:"CommentBody"`
+}
+
+type fieldsCase struct {
+	Id       string          `sf:"Id"`
+	Comments []fieldsComment `sf:"CaseComments"`
+}
+
+type fieldsAccount struct {
+	Name   string         `sf:"Name"`
+	Parent *fieldsAccount `sf:"Parent"`
+}
+
+type fieldsContact struct {
+	Id          string         `sf:"Id"`
+	CreatedDate time.Time      `sf:"CreatedDate"`
+	Account     fieldsAccount  `sf:"Account"`
+	Cases       []fieldsCase   `sf:"Cases"`
+	Ignored     string         `sf:"-"`
+	Partners    []*fieldsCase  `sf:"Partners"`
+	Owner       *fieldsComment `sf:"Owner"`
+}
+
+func TestFieldsOf(t *testing.T) {
+	fields, err := FieldsOf(&[]fieldsContact{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"Id",
+		"CreatedDate",
+		"Account.Name",
+		"Account.Parent.Name",
+		"Account.Parent.Parent.Name",
+		"Account.Parent.Parent.Parent.Name",
+		"Account.Parent.Parent.Parent.Parent.Name",
+		"(SELECT Id FROM Cases)",
+		"(SELECT Id FROM Partners)",
+		"Owner.CommentBody",
+	}
+	if strings.Join(fields, ", ") != strings.Join(want, ", ") {
+		t.Errorf("FieldsOf = %v\nwant       %v", fields, want)
+	}
+}
+
+func TestFieldsOfNoStruct(t *testing.T) {
+	if _, err := FieldsOf("Contact"); err == nil {
+		t.Error("want an error for a string")
+	}
+}
+
+func TestFieldsOfSelfReference(t *testing.T) {
+	fields, err := FieldsOf(fieldsAccount{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"Name",
+		"Parent.Name",
+		"Parent.Parent.Name",
+		"Parent.Parent.Parent.Name",
+		"Parent.Parent.Parent.Parent.Name",
+		"Parent.Parent.Parent.Parent.Parent.Name",
+	}
+	if strings.Join(fields, ", ") != strings.Join(want, ", ") {
+		t.Errorf("FieldsOf = %v\nwant       %v", fields, want)
+	}
+
+	var account fieldsAccount
+	obj := SObject{"Name": "Child", "Parent": map[string]interface{}{"Name": "Root", "Parent": nil}}
+	err = obj.Decode(&account)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if account.Parent == nil || account.Parent.Name != "Root" || account.Parent.Parent != nil {
+		t.Errorf("decoded %+v, want the parent Root without a parent", account)
+	}
+}
@@ -0,0 +1,30 @@
+package types
+
+// Limits describes the org limits keyed by name, e.g. DailyApiRequests.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.214.0.api_rest.meta/api_rest/resources_limits.htm
+type Limits map[string]Limit
+
+// Limit is the allocation and the remaining amount of a single org limit.
+type Limit struct {
+	Max       int `json:"Max"`
+	Remaining int `json:"Remaining"`
+}
+
+// Used returns the consumed amount of the limit.
+func (l Limit) Used() int {
+	return l.Max - l.Remaining
+}
+
+// APIUsage is the daily API request usage reported by salesforce in the Sforce-Limit-Info header.
+type APIUsage struct {
+	Used int
+	Max  int
+}
+
+// Percent returns the share of the daily API requests already used, from 0 to 100. It is 0 while the usage is unknown.
+func (u APIUsage) Percent() float64 {
+	if u.Max <= 0 {
+		return 0
+	}
+	return float64(u.Used) * 100 / float64(u.Max)
+}
@@ -0,0 +1,23 @@
+package types
+
+// SaveResult is returned by salesforce when a record is created.
+// Ref: https://developer.salesforce.com/docs/atlas.en-us.214.0.api_rest.meta/api_rest/dome_sobject_create.htm
+type SaveResult struct {
+	ID      string      `json:"id"`
+	Success bool        `json:"success"`
+	Errors  []SaveError `json:"errors"`
+}
+
+// SaveError describes why a record could not be saved.
+type SaveError struct {
+	StatusCode string   `json:"statusCode"`
+	Message    string   `json:"message"`
+	Fields     []string `json:"fields"`
+}
+
+// UpsertResult is returned when a record is upserted by external ID. Created reports whether a new record was
+// inserted (201) rather than an existing one updated (200/204).
+type UpsertResult struct {
+	SaveResult
+	Created bool `json:"created"`
+}
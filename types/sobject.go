@@ -85,3 +85,16 @@ func (obj *SObject) AttributesField() *SObjectAttributes {
 		return nil
 	}
 }
+
+// Payload returns a copy of the SObject without the keys goforce uses internally, ready to be sent to salesforce.
+func (obj *SObject) Payload() SObject {
+	payload := SObject{}
+	for key, value := range *obj {
+		switch key {
+		case sobjectClientKey, sobjectAttributesKey, sobjectExternalIDFieldNameKey:
+			continue
+		}
+		payload[key] = value
+	}
+	return payload
+}
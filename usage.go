@@ -0,0 +1,128 @@
+package goforce
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/0xArch3r/goforce/api"
+	"github.com/0xArch3r/goforce/types"
+)
+
+// APIUsage returns the daily API usage reported by the last response. It is empty until the first request completed.
+func (c *BaseClient) APIUsage() types.APIUsage {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.usage
+}
+
+// budgetRecheck is how long an exceeded budget is trusted before the usage is fetched again. The daily usage is a
+// rolling 24 hour window, so capacity frees up over time without any request reporting it.
+const budgetRecheck = time.Minute
+
+// checkBudget fails once the last known API usage reached the APIBudget. An exceeded budget is checked again through
+// the limits resource once the usage is older than budgetRecheck.
+func (c *BaseClient) checkBudget(ctx context.Context) error {
+	if c.APIBudget <= 0 {
+		return nil
+	}
+	usage := c.APIUsage()
+	if !c.overBudget(usage) {
+		return nil
+	}
+
+	if c.claimRecheck() {
+		c.refreshUsage(ctx)
+		usage = c.APIUsage()
+		if !c.overBudget(usage) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %d of %d daily api requests used", types.ErrAPIBudgetExceeded, usage.Used, usage.Max)
+}
+
+func (c *BaseClient) overBudget(usage types.APIUsage) bool {
+	return usage.Max > 0 && usage.Percent() >= c.APIBudget
+}
+
+// claimRecheck reports whether the usage is due to be fetched again, and if so marks it as fresh so that concurrent
+// requests keep failing fast instead of fetching it as well.
+func (c *BaseClient) claimRecheck() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if time.Since(c.usageAt) < budgetRecheck {
+		return false
+	}
+	c.usageAt = time.Now()
+	return true
+}
+
+// refreshUsage fetches the limits resource, whose response updates the usage. Failures leave the known usage as is.
+func (c *BaseClient) refreshUsage(ctx context.Context) {
+	res, err := api.LimitsRequest{}.Do(ctx, c)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		return
+	}
+
+	// The body is authoritative in case the response lacks the Sforce-Limit-Info header.
+	limits := types.Limits{}
+	if json.NewDecoder(res.Body).Decode(&limits) != nil {
+		return
+	}
+	if daily, ok := limits["DailyApiRequests"]; ok && daily.Max > 0 {
+		c.mu.Lock()
+		c.usage = types.APIUsage{Used: daily.Used(), Max: daily.Max}
+		c.usageAt = time.Now()
+		c.mu.Unlock()
+	}
+}
+
+// trackUsage is the middleware recording the Sforce-Limit-Info header of every response.
+func (c *BaseClient) trackUsage(next api.Transport) api.Transport {
+	return api.TransportFunc(func(req *http.Request) (*api.Response, error) {
+		res, err := next.Perform(req)
+		if err != nil {
+			return res, err
+		}
+
+		if usage, ok := parseLimitInfo(res.Header.Get("Sforce-Limit-Info")); ok {
+			c.mu.Lock()
+			c.usage = usage
+			c.usageAt = time.Now()
+			c.mu.Unlock()
+		}
+		return res, nil
+	})
+}
+
+// parseLimitInfo reads the api-usage entry of a Sforce-Limit-Info header, e.g. "api-usage=25/15000".
+func parseLimitInfo(header string) (types.APIUsage, bool) {
+	for _, entry := range strings.Split(header, ",") {
+		value, ok := strings.CutPrefix(strings.TrimSpace(entry), "api-usage=")
+		if !ok {
+			continue
+		}
+		used, max, ok := strings.Cut(value, "/")
+		if !ok {
+			return types.APIUsage{}, false
+		}
+		u, err := strconv.Atoi(used)
+		if err != nil {
+			return types.APIUsage{}, false
+		}
+		m, err := strconv.Atoi(max)
+		if err != nil {
+			return types.APIUsage{}, false
+		}
+		return types.APIUsage{Used: u, Max: m}, true
+	}
+	return types.APIUsage{}, false
+}
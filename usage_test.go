@@ -0,0 +1,101 @@
+package goforce
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/0xArch3r/goforce/types"
+)
+
+func TestAPIBudgetRecovers(t *testing.T) {
+	var used, limitCalls atomic.Int32
+	used.Store(90)
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/services/data/v54.0/limits" {
+			limitCalls.Add(1)
+			w.Write([]byte(`{"DailyApiRequests":{"Max":100,"Remaining":` + itoa(100-used.Load()) + `}}`))
+			return
+		}
+		w.Header().Set("Sforce-Limit-Info", "api-usage="+itoa(used.Load())+"/100")
+		w.Write([]byte(`{"done":true,"totalSize":0,"records":[]}`))
+	}, WithAPIBudget(80))
+
+	if _, err := client.Query.Raw("SELECT Id FROM Account"); err != nil {
+		t.Fatal(err)
+	}
+
+	// The usage was just reported, so it is trusted without asking again.
+	_, err := client.Query.Raw("SELECT Id FROM Account")
+	if !errors.Is(err, types.ErrAPIBudgetExceeded) {
+		t.Fatalf("err = %v, want ErrAPIBudgetExceeded", err)
+	}
+	if n := limitCalls.Load(); n != 0 {
+		t.Fatalf("limits fetched %d times before the usage got stale", n)
+	}
+
+	// Still exceeded once the usage is stale: checked once, then trusted again.
+	client.mu.Lock()
+	client.usageAt = time.Now().Add(-budgetRecheck)
+	client.mu.Unlock()
+	for i := 0; i < 3; i++ {
+		_, err = client.Query.Raw("SELECT Id FROM Account")
+		if !errors.Is(err, types.ErrAPIBudgetExceeded) {
+			t.Fatalf("err = %v, want ErrAPIBudgetExceeded", err)
+		}
+	}
+	if n := limitCalls.Load(); n != 1 {
+		t.Fatalf("limits fetched %d times, want 1", n)
+	}
+
+	// Capacity freed up by the rolling window lets requests through again.
+	used.Store(50)
+	client.mu.Lock()
+	client.usageAt = time.Now().Add(-budgetRecheck)
+	client.mu.Unlock()
+	if _, err := client.Query.Raw("SELECT Id FROM Account"); err != nil {
+		t.Fatalf("err = %v after the usage dropped", err)
+	}
+	if usage := client.APIUsage(); usage.Used != 50 {
+		t.Errorf("usage = %+v, want 50 used", usage)
+	}
+}
+
+func itoa(n int32) string {
+	return strconv.Itoa(int(n))
+}
+
+func TestAPIBudgetRecoversWithToolingAPI(t *testing.T) {
+	var used atomic.Int32
+	used.Store(90)
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/services/data/v54.0/limits":
+			w.Write([]byte(`{"DailyApiRequests":{"Max":100,"Remaining":` + itoa(100-used.Load()) + `}}`))
+		case "/services/data/v54.0/tooling/query":
+			w.Header().Set("Sforce-Limit-Info", "api-usage="+itoa(used.Load())+"/100")
+			w.Write([]byte(`{"done":true,"totalSize":0,"records":[]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`[{"errorCode":"NOT_FOUND","message":"The requested resource does not exist"}]`))
+		}
+	}, WithToolingAPI(), WithAPIBudget(80))
+
+	if _, err := client.Query.Raw("SELECT Id FROM ApexClass"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Query.Raw("SELECT Id FROM ApexClass"); !errors.Is(err, types.ErrAPIBudgetExceeded) {
+		t.Fatalf("err = %v, want ErrAPIBudgetExceeded", err)
+	}
+
+	used.Store(50)
+	client.mu.Lock()
+	client.usageAt = time.Now().Add(-budgetRecheck)
+	client.mu.Unlock()
+	if _, err := client.Query.Raw("SELECT Id FROM ApexClass"); err != nil {
+		t.Fatalf("err = %v after the usage dropped", err)
+	}
+}